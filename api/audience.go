@@ -0,0 +1,66 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// defaultAudienceWindow is how far back audience samples are returned when
+// no since is given.
+const defaultAudienceWindow = 24 * time.Hour
+
+func (s *Server) registerAudienceRoutes(traders *gin.RouterGroup) {
+	traders.GET("/events/:id/audience", s.eventAudience)
+	traders.GET("/events/:id/audience/samples", s.eventAudienceSamples)
+}
+
+func (s *Server) eventAudience(c *gin.Context) {
+	eventID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	audience, err := s.audience.EventAudience(c.Request.Context(), eventID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEventNotFound):
+			respondError(c, http.StatusNotFound, fmt.Sprintf("event %d not found", eventID))
+		case errors.Is(err, services.ErrPresenceUnavailable):
+			respondError(c, http.StatusBadGateway, err.Error())
+		default:
+			respondError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, audience)
+}
+
+func (s *Server) eventAudienceSamples(c *gin.Context) {
+	eventID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	since := time.Now().Add(-defaultAudienceWindow)
+	if raw := c.Query("since"); raw != "" {
+		t, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("invalid since %q, must be an RFC 3339 timestamp", raw))
+			return
+		}
+		since = t
+	}
+
+	samples, err := s.audience.Samples(eventID, since)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, samples)
+}
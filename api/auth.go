@@ -0,0 +1,104 @@
+package api
+
+import (
+	"errors"
+	"github.com/VaheMuradyan/Sport/auth"
+	"github.com/VaheMuradyan/Sport/models"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+func (s *Server) register(c *gin.Context) {
+	var req models.UserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := s.userService.Register(req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrUsernameTaken) {
+			respondError(c, http.StatusConflict, err.Error())
+			return
+		}
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+func (s *Server) login(c *gin.Context) {
+	var req models.UserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := s.userService.Authenticate(req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			respondError(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	token, expiresAt, err := s.tokens.Issue(user.ID, user.Username)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.LoginResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		User:      *user,
+	})
+}
+
+// requireAuth rejects requests without a valid bearer token and stores the
+// caller's Identity in the request context.
+func (s *Server) requireAuth(c *gin.Context) {
+	token, ok := auth.BearerToken(c.GetHeader("Authorization"))
+	if !ok {
+		respondError(c, http.StatusUnauthorized, "missing bearer token")
+		return
+	}
+
+	identity, err := s.tokens.Verify(token)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	c.Request = c.Request.WithContext(auth.WithIdentity(c.Request.Context(), identity))
+	c.Next()
+}
+
+// identity returns the caller authenticated by requireAuth.
+func identity(c *gin.Context) *auth.Identity {
+	identity, _ := auth.IdentityFromContext(c.Request.Context())
+	return identity
+}
+
+// requireRole lets the request through only when the authenticated caller
+// has one of the roles. Denials are audited by the PermissionService.
+func (s *Server) requireRole(action string, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var resourceID uint
+		if id, err := strconv.ParseUint(c.Param("id"), 10, 32); err == nil {
+			resourceID = uint(id)
+		}
+
+		err := s.permissions.RequireRole(identity(c).UserID, action, c.FullPath(), resourceID, roles...)
+		if err != nil {
+			respondError(c, http.StatusForbidden, err.Error())
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,307 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+type teamIDsRequest struct {
+	TeamIDs []uint `json:"team_ids"`
+}
+
+func (s *Server) registerCatalogueRoutes(read, write *gin.RouterGroup) {
+	sports := &resource[models.Sport]{
+		db:   s.db,
+		name: "sport",
+		includes: map[string]string{
+			"competitions":     "Competitions",
+			"teams":            "Teams",
+			"market_templates": "MarketTemplates",
+		},
+		filters: map[string]filterFunc{
+			"code": stringFilter("code"),
+		},
+		created: services.AddDefaultMarketTemplates,
+	}
+
+	countries := &resource[models.Country]{
+		db:   s.db,
+		name: "country",
+		includes: map[string]string{
+			"competitions": "Competitions",
+		},
+		filters: map[string]filterFunc{
+			"code": stringFilter("code"),
+		},
+	}
+
+	competitions := &resource[models.Competition]{
+		db:   s.db,
+		name: "competition",
+		includes: map[string]string{
+			"country": "Country",
+			"sport":   "Sport",
+			"teams":   "Teams",
+			"events":  "Events",
+		},
+		filters: map[string]filterFunc{
+			"sport_id":   uintFilter("sport_id"),
+			"country_id": uintFilter("country_id"),
+			"active":     boolFilter("active"),
+		},
+	}
+
+	teams := &resource[models.Team]{
+		db:   s.db,
+		name: "team",
+		includes: map[string]string{
+			"country":      "Country",
+			"competitions": "Competitions",
+			"sports":       "Sports",
+		},
+		filters: map[string]filterFunc{
+			"country_id":     uintFilter("country_id"),
+			"competition_id": joinTableFilter("competition_teams", "team_id", "competition_id"),
+			"sport_id":       joinTableFilter("sport_teams", "team_id", "sport_id"),
+		},
+	}
+
+	events := &resource[models.Event]{
+		db:   s.db,
+		name: "event",
+		includes: map[string]string{
+			"competition":         "Competition",
+			"competition.sport":   "Competition.Sport",
+			"competition.country": "Competition.Country",
+			"markets":             "Markets",
+			"markets.selections":  "Markets.Selections",
+			"teams":               "Teams",
+		},
+		filters: map[string]filterFunc{
+			"competition_id": uintFilter("competition_id"),
+			"sport_id":       subqueryFilter("competition_id", &models.Competition{}, "sport_id"),
+			"country_id":     subqueryFilter("competition_id", &models.Competition{}, "country_id"),
+			"status":         stringFilter("status"),
+			"is_live":        boolFilter("is_live"),
+		},
+		readOnly: []string{"sequence", "status", "is_live"},
+		created:  generateMarkets,
+	}
+
+	markets := &resource[models.Market]{
+		db:   s.db,
+		name: "market",
+		includes: map[string]string{
+			"event":               "Event",
+			"event.competition":   "Event.Competition",
+			"selections":          "Selections",
+			"team":                "Team",
+			"coefficient_history": "CoefficientHistory",
+		},
+		filters: map[string]filterFunc{
+			"event_id":       uintFilter("event_id"),
+			"team_id":        uintFilter("team_id"),
+			"period":         stringFilter("period"),
+			"type":           stringFilter("type"),
+			"active":         boolFilter("active"),
+			"market_status":  stringFilter("status"),
+			"competition_id": subqueryFilter("event_id", &models.Event{}, "competition_id"),
+			"sport_id":       marketCompetitionFilter("sport_id"),
+			"country_id":     marketCompetitionFilter("country_id"),
+			"status":         marketEventFilter(stringFilter("status")),
+			"is_live":        marketEventFilter(boolFilter("is_live")),
+		},
+		readOnly:   []string{"version", "status", "active"},
+		createOnly: []string{"line"},
+		version:    "version",
+		validate:   services.ValidateMarket,
+	}
+
+	selections := &resource[models.Selection]{
+		db:   s.db,
+		name: "selection",
+		includes: map[string]string{
+			"market":              "Market",
+			"market.event":        "Market.Event",
+			"coefficient_history": "CoefficientHistory",
+		},
+		filters: map[string]filterFunc{
+			"market_id": uintFilter("market_id"),
+			"event_id":  subqueryFilter("market_id", &models.Market{}, "event_id"),
+		},
+		// Odds are set on create and repriced through the coefficient
+		// endpoints only, which check, record and publish every change.
+		readOnly:   []string{"version", "previous_coefficient", "last_updated"},
+		createOnly: []string{"current_coefficient", "market_id"},
+		version:    "version",
+		validate:   services.ValidateSelection,
+	}
+
+	marketTemplates := &resource[models.MarketTemplate]{
+		db:   s.db,
+		name: "market template",
+		includes: map[string]string{
+			"sport":      "Sport",
+			"selections": "Selections",
+		},
+		filters: map[string]filterFunc{
+			"sport_id": uintFilter("sport_id"),
+			"type":     stringFilter("type"),
+			"active":   boolFilter("active"),
+		},
+		validate: services.ValidateMarketTemplate,
+	}
+
+	selectionTemplates := &resource[models.SelectionTemplate]{
+		db:   s.db,
+		name: "selection template",
+		filters: map[string]filterFunc{
+			"market_template_id": uintFilter("market_template_id"),
+		},
+		validate: services.ValidateSelectionTemplate,
+	}
+
+	sports.register(read, write, "/sports")
+	countries.register(read, write, "/countries")
+	competitions.register(read, write, "/competitions")
+	teams.register(read, write, "/teams")
+	events.register(read, write, "/events")
+	markets.register(read, write, "/markets")
+	selections.register(read, write, "/selections")
+	marketTemplates.register(read, write, "/market_templates")
+	selectionTemplates.register(read, write, "/selection_templates")
+
+	write.PUT("/competitions/:id/teams", s.replaceTeams(func() interface{} { return &models.Competition{} }, nil))
+	// Per team markets are generated once the event has its teams.
+	write.PUT("/events/:id/teams", s.replaceTeams(func() interface{} { return &models.Event{} }, func(tx *gorm.DB, id uint) error {
+		_, err := services.GenerateEventMarkets(tx, id)
+		return err
+	}))
+	write.POST("/events/:id/markets/generate", s.generateEventMarkets)
+}
+
+// generateMarkets creates the markets of a new event from the templates of
+// its sport and returns them with the event.
+func generateMarkets(tx *gorm.DB, event *models.Event) error {
+	markets, err := services.GenerateEventMarkets(tx, event.ID)
+	if err != nil {
+		return err
+	}
+	event.Markets = markets
+	return nil
+}
+
+// generateEventMarkets creates the markets of templates added since the event
+// was created.
+func (s *Server) generateEventMarkets(c *gin.Context) {
+	eventID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var markets []models.Market
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		markets, err = services.GenerateEventMarkets(tx, eventID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			respondError(c, http.StatusNotFound, fmt.Sprintf("event %d not found", eventID))
+			return
+		}
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"markets": markets})
+}
+
+// marketCompetitionFilter matches markets whose event belongs to a
+// competition with the given column value.
+func marketCompetitionFilter(column string) filterFunc {
+	return func(value string) (func(*gorm.DB) *gorm.DB, error) {
+		id, err := parseUintValue(column, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(tx *gorm.DB) *gorm.DB {
+			session := tx.Session(&gorm.Session{NewDB: true})
+			competitions := session.Model(&models.Competition{}).Select("id").Where(column+" = ?", id)
+			events := session.Model(&models.Event{}).Select("id").Where("competition_id IN (?)", competitions)
+			return tx.Where("event_id IN (?)", events)
+		}, nil
+	}
+}
+
+// marketEventFilter applies an event filter to the markets' events.
+func marketEventFilter(eventFilter filterFunc) filterFunc {
+	return func(value string) (func(*gorm.DB) *gorm.DB, error) {
+		scope, err := eventFilter(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(tx *gorm.DB) *gorm.DB {
+			events := tx.Session(&gorm.Session{NewDB: true}).Model(&models.Event{}).Select("id").Scopes(scope)
+			return tx.Where("event_id IN (?)", events)
+		}, nil
+	}
+}
+
+// replaceTeams sets the teams linked to a competition or an event. linked, if
+// set, runs in the same transaction once the teams are replaced.
+func (s *Server) replaceTeams(newModel func() interface{}, linked func(tx *gorm.DB, id uint) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		var req teamIDsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var teams []models.Team
+		if len(req.TeamIDs) > 0 {
+			if err := s.db.Find(&teams, req.TeamIDs).Error; err != nil {
+				respondError(c, http.StatusInternalServerError, fmt.Sprintf("failed to load teams: %v", err))
+				return
+			}
+			if len(teams) != len(req.TeamIDs) {
+				respondError(c, http.StatusBadRequest, "one or more teams do not exist")
+				return
+			}
+		}
+
+		model := newModel()
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.First(model, id).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(model).Association("Teams").Replace(teams); err != nil {
+				return err
+			}
+			if linked == nil {
+				return nil
+			}
+			return linked(tx, id)
+		})
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(c, http.StatusNotFound, fmt.Sprintf("record %d not found", id))
+				return
+			}
+			respondError(c, http.StatusInternalServerError, fmt.Sprintf("failed to link teams: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": id, "team_ids": req.TeamIDs})
+	}
+}
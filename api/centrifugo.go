@@ -0,0 +1,54 @@
+package api
+
+import (
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (s *Server) connectionToken(c *gin.Context) {
+	token, expiresAt, err := s.centrifugoTokens.ConnectionToken(identity(c).UserID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.TokenResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+func (s *Server) subscriptionToken(c *gin.Context) {
+	var req models.SubscriptionTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !services.IsOddsChannel(req.Channel) {
+		respondError(c, http.StatusForbidden, fmt.Sprintf("channel %q is not available", req.Channel))
+		return
+	}
+
+	token, expiresAt, err := s.centrifugoTokens.SubscriptionToken(identity(c).UserID, req.Channel)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.TokenResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+// allowBrowserClients adds the CORS headers the browser odds client needs to
+// log in and fetch tokens from another origin.
+func allowBrowserClients(c *gin.Context) {
+	c.Header("Access-Control-Allow-Origin", "*")
+	c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type")
+	c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+
+	if c.Request.Method == http.MethodOptions {
+		c.AbortWithStatus(http.StatusNoContent)
+		return
+	}
+	c.Next()
+}
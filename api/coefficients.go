@@ -0,0 +1,216 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (s *Server) updateSelectionCoefficient(c *gin.Context) {
+	selectionID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CoefficientUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.SelectionID != 0 && req.SelectionID != selectionID {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("selection_id %d does not match selection %d in path", req.SelectionID, selectionID))
+		return
+	}
+
+	s.updateCoefficientOf(c, selectionID, req)
+}
+
+// updateCoefficient sets the odds of a market from before selections, which
+// has a single selection.
+func (s *Server) updateCoefficient(c *gin.Context) {
+	marketID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CoefficientUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.MarketID != 0 && req.MarketID != marketID {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("market_id %d does not match market %d in path", req.MarketID, marketID))
+		return
+	}
+
+	selection, err := s.coefficients.MarketSelection(marketID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrMarketNotFound):
+			respondError(c, http.StatusNotFound, fmt.Sprintf("market %d not found", marketID))
+		case errors.Is(err, services.ErrSelectionNotFound):
+			respondError(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrAmbiguousSelection):
+			respondError(c, http.StatusBadRequest, err.Error())
+		default:
+			respondError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	s.updateCoefficientOf(c, selection.ID, req)
+}
+
+func (s *Server) updateCoefficientOf(c *gin.Context, selectionID uint, req models.CoefficientUpdateRequest) {
+	response, err := s.coefficientUpdater.UpdateCoefficient(selectionID, req.NewCoefficient, identity(c).UserID, req.ExpectedVersion)
+	if err != nil {
+		var conflict *services.VersionConflictError
+		switch {
+		case errors.As(err, &conflict) && conflict.Selection != nil:
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+				"error":               err.Error(),
+				"market_id":           conflict.Selection.MarketID,
+				"selection_id":        conflict.Selection.ID,
+				"current_version":     conflict.Selection.Version,
+				"current_coefficient": conflict.Selection.CurrentCoefficient,
+				"last_updated":        conflict.Selection.LastUpdated,
+			})
+		case errors.Is(err, services.ErrSelectionNotFound):
+			respondError(c, http.StatusNotFound, fmt.Sprintf("selection %d not found", selectionID))
+		case errors.Is(err, services.ErrPermissionDenied):
+			respondError(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrCoefficientOutOfBounds):
+			respondError(c, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, services.ErrMarketNotOpen):
+			respondError(c, http.StatusConflict, err.Error())
+		default:
+			respondError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (s *Server) setMarketStatus(c *gin.Context) {
+	marketID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.MarketStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := s.coefficientUpdater.SetMarketStatus(marketID, req.Status, req.Reason, identity(c).UserID, req.ExpectedVersion)
+	if err != nil {
+		var conflict *services.VersionConflictError
+		switch {
+		case errors.As(err, &conflict):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+				"error":           err.Error(),
+				"market_id":       conflict.Market.ID,
+				"current_version": conflict.Market.Version,
+				"current_status":  conflict.Market.Status,
+			})
+		case errors.Is(err, services.ErrMarketNotFound):
+			respondError(c, http.StatusNotFound, fmt.Sprintf("market %d not found", marketID))
+		case errors.Is(err, services.ErrPermissionDenied):
+			respondError(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidTransition):
+			respondError(c, http.StatusConflict, err.Error())
+		default:
+			respondError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// moveMarketLine moves the line of a market, optionally with the odds of its
+// selections at the new line.
+func (s *Server) moveMarketLine(c *gin.Context) {
+	marketID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.MarketLineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changes := make([]services.CoefficientChange, len(req.Coefficients))
+	for i, change := range req.Coefficients {
+		changes[i] = services.CoefficientChange{
+			SelectionID:     change.SelectionID,
+			NewCoefficient:  change.NewCoefficient,
+			ExpectedVersion: change.ExpectedVersion,
+		}
+	}
+
+	response, err := s.coefficientUpdater.MoveMarketLine(marketID, *req.Line, changes, identity(c).UserID, req.ExpectedVersion)
+	if err != nil {
+		var conflict *services.VersionConflictError
+		switch {
+		case errors.As(err, &conflict) && conflict.Selection != nil:
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+				"error":               err.Error(),
+				"market_id":           conflict.Selection.MarketID,
+				"selection_id":        conflict.Selection.ID,
+				"current_version":     conflict.Selection.Version,
+				"current_coefficient": conflict.Selection.CurrentCoefficient,
+			})
+		case errors.As(err, &conflict):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+				"error":           err.Error(),
+				"market_id":       conflict.Market.ID,
+				"current_version": conflict.Market.Version,
+				"current_line":    conflict.Market.Line,
+			})
+		case errors.Is(err, services.ErrMarketNotFound):
+			respondError(c, http.StatusNotFound, fmt.Sprintf("market %d not found", marketID))
+		case errors.Is(err, services.ErrPermissionDenied):
+			respondError(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidMarketParams), errors.Is(err, services.ErrInvalidBatch):
+			respondError(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrCoefficientOutOfBounds):
+			respondError(c, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, services.ErrMarketNotOpen):
+			respondError(c, http.StatusConflict, err.Error())
+		default:
+			respondError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (s *Server) lineHistory(c *gin.Context) {
+	marketID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	history, err := s.coefficients.GetLineHistory(marketID)
+	if err != nil {
+		if errors.Is(err, services.ErrMarketNotFound) {
+			respondError(c, http.StatusNotFound, fmt.Sprintf("market %d not found", marketID))
+			return
+		}
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"line_history": history})
+}
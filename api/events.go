@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (s *Server) setEventStatus(c *gin.Context) {
+	eventID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.EventStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := s.coefficientUpdater.SetEventStatus(eventID, req.Status, req.Reason, identity(c).UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEventNotFound):
+			respondError(c, http.StatusNotFound, fmt.Sprintf("event %d not found", eventID))
+		case errors.Is(err, services.ErrPermissionDenied):
+			respondError(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidTransition):
+			respondError(c, http.StatusConflict, err.Error())
+		default:
+			respondError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultReplayLimit = 100
+	maxReplayLimit     = 1000
+)
+
+// HistoryResponse is a page of replayed updates. NextOffset is passed as
+// offset to fetch the next page while HasMore is set.
+type HistoryResponse struct {
+	Channel    string                    `json:"channel"`
+	Updates    []services.ReplayedUpdate `json:"updates"`
+	NextOffset uint                      `json:"next_offset"`
+	HasMore    bool                      `json:"has_more"`
+}
+
+// replayHistory is the fallback for clients whose Centrifugo subscription
+// could not be recovered. It replays the coefficient history of a channel
+// since a timestamp (RFC 3339) or after an offset from a previous page.
+func (s *Server) replayHistory(c *gin.Context) {
+	query := services.HistoryQuery{
+		Channel: c.Query("channel"),
+		Limit:   defaultReplayLimit,
+	}
+	if query.Channel == "" {
+		respondError(c, http.StatusBadRequest, "channel is required")
+		return
+	}
+
+	since, offset := c.Query("since"), c.Query("offset")
+	switch {
+	case since != "" && offset != "":
+		respondError(c, http.StatusBadRequest, "since and offset are mutually exclusive")
+		return
+	case since != "":
+		t, err := time.Parse(time.RFC3339Nano, since)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("invalid since %q, must be an RFC 3339 timestamp", since))
+			return
+		}
+		query.Since = t
+	case offset != "":
+		id, err := strconv.ParseUint(offset, 10, 32)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("invalid offset %q", offset))
+			return
+		}
+		query.AfterID = uint(id)
+	default:
+		respondError(c, http.StatusBadRequest, "since or offset is required")
+		return
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxReplayLimit {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("invalid limit %q, must be between 1 and %d", raw, maxReplayLimit))
+			return
+		}
+		query.Limit = limit
+	}
+
+	updates, err := s.coefficients.ReplayHistory(query)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownChannel) {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := HistoryResponse{
+		Channel:    query.Channel,
+		Updates:    updates,
+		NextOffset: query.AfterID,
+		HasMore:    len(updates) == query.Limit,
+	}
+	if len(updates) > 0 {
+		response.NextOffset = updates[len(updates)-1].Offset
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (s *Server) recordIncident(c *gin.Context) {
+	eventID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.IncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	message, err := s.coefficientUpdater.RecordIncident(eventID, req, identity(c).UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEventNotFound):
+			respondError(c, http.StatusNotFound, fmt.Sprintf("event %d not found", eventID))
+		case errors.Is(err, services.ErrPermissionDenied):
+			respondError(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidIncident):
+			respondError(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrEventNotLive):
+			respondError(c, http.StatusConflict, err.Error())
+		default:
+			respondError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+func (s *Server) listIncidents(c *gin.Context) {
+	eventID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	incidents, err := s.coefficients.ListIncidents(eventID)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			respondError(c, http.StatusNotFound, fmt.Sprintf("event %d not found", eventID))
+			return
+		}
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"incidents": incidents})
+}
+
+// eventScore returns the status, periods and score of an event. Clients keep
+// it current with the score carried by incident messages.
+func (s *Server) eventScore(c *gin.Context) {
+	eventID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	board, err := s.coefficients.GetScoreboard(eventID)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			respondError(c, http.StatusNotFound, fmt.Sprintf("event %d not found", eventID))
+			return
+		}
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
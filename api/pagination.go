@@ -0,0 +1,49 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type Pagination struct {
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+	Total    int64 `json:"total"`
+}
+
+type ListResponse struct {
+	Data       interface{} `json:"data"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+func parsePagination(c *gin.Context) (Pagination, error) {
+	p := Pagination{Page: 1, PageSize: defaultPageSize}
+
+	if raw := c.Query("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return p, fmt.Errorf("invalid page %q", raw)
+		}
+		p.Page = page
+	}
+
+	if raw := c.Query("page_size"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil || size < 1 || size > maxPageSize {
+			return p, fmt.Errorf("invalid page_size %q, must be between 1 and %d", raw, maxPageSize)
+		}
+		p.PageSize = size
+	}
+
+	return p, nil
+}
+
+func (p Pagination) offset() int {
+	return (p.Page - 1) * p.PageSize
+}
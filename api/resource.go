@@ -0,0 +1,364 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// filterFunc parses the value of a single query parameter into a scope that
+// narrows a list query.
+type filterFunc func(value string) (func(*gorm.DB) *gorm.DB, error)
+
+// resource serves list/get/create/update/delete endpoints for one catalogue
+// model. Relations are never written through it: creates and updates omit
+// associations, and linking is done with dedicated endpoints. Columns listed
+// in readOnly are maintained by the services and never written either, those
+// in createOnly are written on create only; the version column, if any, is
+// incremented by every update. validate, if set, checks the record as written
+// before the write commits; its errors are reported as bad requests. created,
+// if set, runs in the transaction of a create, for records that come with
+// the new one.
+type resource[T any] struct {
+	db         *gorm.DB
+	name       string
+	includes   map[string]string
+	filters    map[string]filterFunc
+	readOnly   []string
+	createOnly []string
+	version    string
+	validate   func(tx *gorm.DB, item *T) error
+	created    func(tx *gorm.DB, item *T) error
+}
+
+func (r *resource[T]) register(read, write *gin.RouterGroup, path string) {
+	read.GET(path, r.list)
+	read.GET(path+"/:id", r.get)
+	write.POST(path, r.create)
+	write.PUT(path+"/:id", r.update)
+	write.DELETE(path+"/:id", r.delete)
+}
+
+func (r *resource[T]) list(c *gin.Context) {
+	page, err := parsePagination(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filtered, err := r.applyFilters(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	preload, err := r.applyIncludes(c.Query("include"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var total int64
+	if err := r.db.Model(new(T)).Scopes(filtered).Count(&total).Error; err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("failed to count %s: %v", r.name, err))
+		return
+	}
+
+	items := make([]T, 0)
+	err = r.db.Scopes(filtered, preload).
+		Order("id").
+		Offset(page.offset()).
+		Limit(page.PageSize).
+		Find(&items).Error
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list %s: %v", r.name, err))
+		return
+	}
+
+	page.Total = total
+	c.JSON(http.StatusOK, ListResponse{Data: items, Pagination: page})
+}
+
+func (r *resource[T]) get(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	preload, err := r.applyIncludes(c.Query("include"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var item T
+	if err := r.db.Scopes(preload).First(&item, id).Error; err != nil {
+		r.respondLoadError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (r *resource[T]) create(c *gin.Context) {
+	var item T
+	if err := c.ShouldBindJSON(&item); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	resetModel(&item)
+
+	var invalid error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(append(r.readOnly, clause.Associations)...).Create(&item).Error; err != nil {
+			return err
+		}
+		if invalid = r.check(tx, &item); invalid != nil {
+			return invalid
+		}
+		if r.created == nil {
+			return nil
+		}
+		return r.created(tx, &item)
+	})
+	if invalid != nil {
+		respondError(c, http.StatusBadRequest, invalid.Error())
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("failed to create %s: %v", r.name, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// update replaces every column of the record with the request body.
+func (r *resource[T]) update(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var existing T
+	if err := r.db.First(&existing, id).Error; err != nil {
+		r.respondLoadError(c, id, err)
+		return
+	}
+
+	var input T
+	if err := c.ShouldBindJSON(&input); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	resetModel(&input)
+
+	omit := append([]string{"id", "created_at", "deleted_at", clause.Associations}, r.readOnly...)
+	var invalid error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&existing).
+			Select("*").
+			Omit(append(omit, r.createOnly...)...).
+			Updates(&input).Error
+		if err != nil {
+			return err
+		}
+		if r.version != "" {
+			if err := tx.Model(&existing).UpdateColumn(r.version, gorm.Expr(r.version+" + 1")).Error; err != nil {
+				return err
+			}
+		}
+		if r.validate == nil {
+			return nil
+		}
+		var written T
+		if err := tx.First(&written, id).Error; err != nil {
+			return err
+		}
+		invalid = r.check(tx, &written)
+		return invalid
+	})
+	if invalid != nil {
+		respondError(c, http.StatusBadRequest, invalid.Error())
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("failed to update %s: %v", r.name, err))
+		return
+	}
+
+	var updated T
+	if err := r.db.First(&updated, id).Error; err != nil {
+		r.respondLoadError(c, id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (r *resource[T]) delete(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	result := r.db.Delete(new(T), id)
+	if result.Error != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete %s: %v", r.name, result.Error))
+		return
+	}
+	if result.RowsAffected == 0 {
+		respondError(c, http.StatusNotFound, fmt.Sprintf("%s %d not found", r.name, id))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (r *resource[T]) check(tx *gorm.DB, item *T) error {
+	if r.validate == nil {
+		return nil
+	}
+	return r.validate(tx, item)
+}
+
+func (r *resource[T]) applyFilters(c *gin.Context) (func(*gorm.DB) *gorm.DB, error) {
+	var scopes []func(*gorm.DB) *gorm.DB
+	for param, filter := range r.filters {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		scope, err := filter(value)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, scope)
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Scopes(scopes...)
+	}, nil
+}
+
+func (r *resource[T]) applyIncludes(raw string) (func(*gorm.DB) *gorm.DB, error) {
+	var associations []string
+	if raw != "" {
+		for _, name := range strings.Split(raw, ",") {
+			association, ok := r.includes[strings.TrimSpace(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown include %q for %s", name, r.name)
+			}
+			associations = append(associations, association)
+		}
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		for _, association := range associations {
+			tx = tx.Preload(association)
+		}
+		return tx
+	}, nil
+}
+
+func (r *resource[T]) respondLoadError(c *gin.Context, id uint, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		respondError(c, http.StatusNotFound, fmt.Sprintf("%s %d not found", r.name, id))
+		return
+	}
+	respondError(c, http.StatusInternalServerError, fmt.Sprintf("failed to load %s: %v", r.name, err))
+}
+
+func parseID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("invalid id %q", c.Param("id")))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// resetModel clears the embedded gorm.Model so clients cannot choose IDs or
+// timestamps through the request body.
+func resetModel(item interface{}) {
+	model := reflect.ValueOf(item).Elem().FieldByName("Model")
+	if model.IsValid() && model.CanSet() {
+		model.Set(reflect.Zero(model.Type()))
+	}
+}
+
+func uintFilter(column string) filterFunc {
+	return func(value string) (func(*gorm.DB) *gorm.DB, error) {
+		id, err := parseUintValue(column, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(tx *gorm.DB) *gorm.DB {
+			return tx.Where(column+" = ?", id)
+		}, nil
+	}
+}
+
+func boolFilter(column string) filterFunc {
+	return func(value string) (func(*gorm.DB) *gorm.DB, error) {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", column, value)
+		}
+		return func(tx *gorm.DB) *gorm.DB {
+			return tx.Where(column+" = ?", b)
+		}, nil
+	}
+}
+
+func stringFilter(column string) filterFunc {
+	return func(value string) (func(*gorm.DB) *gorm.DB, error) {
+		return func(tx *gorm.DB) *gorm.DB {
+			return tx.Where(column+" = ?", value)
+		}, nil
+	}
+}
+
+// subqueryFilter matches rows whose column is in the IDs of model rows where
+// subColumn equals the value, e.g. events whose competition has a sport.
+func subqueryFilter(column string, model interface{}, subColumn string) filterFunc {
+	return func(value string) (func(*gorm.DB) *gorm.DB, error) {
+		id, err := parseUintValue(subColumn, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(tx *gorm.DB) *gorm.DB {
+			sub := tx.Session(&gorm.Session{NewDB: true}).Model(model).Select("id").Where(subColumn+" = ?", id)
+			return tx.Where(column+" IN (?)", sub)
+		}, nil
+	}
+}
+
+// joinTableFilter matches rows linked to the value through a many2many join
+// table.
+func joinTableFilter(joinTable, ownColumn, otherColumn string) filterFunc {
+	return func(value string) (func(*gorm.DB) *gorm.DB, error) {
+		id, err := parseUintValue(otherColumn, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(tx *gorm.DB) *gorm.DB {
+			sub := tx.Session(&gorm.Session{NewDB: true}).Table(joinTable).Select(ownColumn).Where(otherColumn+" = ?", id)
+			return tx.Where("id IN (?)", sub)
+		}, nil
+	}
+}
+
+func parseUintValue(name, value string) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return uint(id), nil
+}
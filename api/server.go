@@ -0,0 +1,72 @@
+package api
+
+import (
+	"github.com/VaheMuradyan/Sport/auth"
+	"github.com/VaheMuradyan/Sport/models"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Server struct {
+	db                 *gorm.DB
+	coefficients       *services.CoefficientService
+	coefficientUpdater *services.CoefficientUpdater
+	audience           *services.AudienceService
+	userService        *services.UserService
+	permissions        *services.PermissionService
+	tokens             *auth.TokenManager
+	centrifugoTokens   *auth.CentrifugoTokens
+}
+
+func NewServer(db *gorm.DB, coefficients *services.CoefficientService, coefficientUpdater *services.CoefficientUpdater, audience *services.AudienceService, userService *services.UserService, permissions *services.PermissionService, tokens *auth.TokenManager, centrifugoTokens *auth.CentrifugoTokens) *Server {
+	return &Server{
+		db:                 db,
+		coefficients:       coefficients,
+		coefficientUpdater: coefficientUpdater,
+		audience:           audience,
+		userService:        userService,
+		permissions:        permissions,
+		tokens:             tokens,
+		centrifugoTokens:   centrifugoTokens,
+	}
+}
+
+// RegisterRoutes mounts the API under /api/v1. Reads are public; every
+// write requires a bearer token from /api/v1/auth/login. Catalogue and user
+// management are reserved to admins, audience statistics to admins and
+// traders, while coefficient updates and market and event status changes are
+// checked against the caller's trading permissions.
+func (s *Server) RegisterRoutes(r *gin.Engine) {
+	r.Use(allowBrowserClients)
+
+	v1 := r.Group("/api/v1")
+	authenticated := v1.Group("", s.requireAuth)
+	catalogueAdmin := authenticated.Group("", s.requireRole("catalogue.write", models.RoleAdmin))
+	userAdmin := authenticated.Group("", s.requireRole("users.manage", models.RoleAdmin))
+	traders := authenticated.Group("", s.requireRole("audience.read", models.RoleAdmin, models.RoleTrader))
+
+	v1.POST("/auth/register", s.register)
+	v1.POST("/auth/login", s.login)
+
+	s.registerCatalogueRoutes(v1, catalogueAdmin)
+	s.registerUserRoutes(userAdmin)
+	s.registerAudienceRoutes(traders)
+	v1.GET("/history", s.replayHistory)
+	v1.GET("/events/:id/snapshot", s.eventSnapshot)
+	v1.GET("/events/:id/score", s.eventScore)
+	v1.GET("/events/:id/incidents", s.listIncidents)
+	v1.GET("/markets/:id/line_history", s.lineHistory)
+	authenticated.POST("/selections/:id/coefficient", s.updateSelectionCoefficient)
+	authenticated.POST("/markets/:id/coefficient", s.updateCoefficient)
+	authenticated.POST("/markets/:id/status", s.setMarketStatus)
+	authenticated.POST("/markets/:id/line", s.moveMarketLine)
+	authenticated.POST("/events/:id/status", s.setEventStatus)
+	authenticated.POST("/events/:id/incidents", s.recordIncident)
+	authenticated.POST("/centrifugo/connection-token", s.connectionToken)
+	authenticated.POST("/centrifugo/subscription-token", s.subscriptionToken)
+}
+
+func respondError(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, gin.H{"error": message})
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// eventSnapshot returns the current board of an event. Clients subscribe to
+// the event channel first, then fetch the snapshot and apply the updates with
+// a greater sequence on top of it.
+func (s *Server) eventSnapshot(c *gin.Context) {
+	eventID, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	snapshot, err := s.coefficients.GetEventSnapshot(eventID)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			respondError(c, http.StatusNotFound, fmt.Sprintf("event %d not found", eventID))
+			return
+		}
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"errors"
+	"github.com/VaheMuradyan/Sport/models"
+	"github.com/VaheMuradyan/Sport/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+func (s *Server) registerUserRoutes(admin *gin.RouterGroup) {
+	admin.GET("/users", s.listUsers)
+	admin.GET("/users/:id", s.getUser)
+	admin.PUT("/users/:id/role", s.setUserRole)
+	admin.POST("/users/:id/permissions", s.addPermission)
+	admin.DELETE("/users/:id/permissions/:permission_id", s.removePermission)
+}
+
+func (s *Server) listUsers(c *gin.Context) {
+	users, err := s.userService.ListUsers()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func (s *Server) getUser(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	user, err := s.userService.GetUser(id)
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (s *Server) setUserRole(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := s.userService.SetRole(id, req.Role)
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (s *Server) addPermission(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.TradingPermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	permission, err := s.userService.AddPermission(id, req.SportID, req.CompetitionID)
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, permission)
+}
+
+func (s *Server) removePermission(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	permissionID, err := strconv.ParseUint(c.Param("permission_id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "invalid permission_id")
+		return
+	}
+
+	if err := s.userService.RemovePermission(id, uint(permissionID)); err != nil {
+		respondUserError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func respondUserError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrPermissionNotFound):
+		respondError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidPermission):
+		respondError(c, http.StatusBadRequest, err.Error())
+	default:
+		respondError(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"strconv"
+	"time"
+)
+
+// CentrifugoTokens signs connection and subscription JWTs that Centrifugo
+// verifies with the same HMAC secret (token_hmac_secret_key). With recovery
+// set, subscription tokens turn on positioning and recovery for the channel,
+// which must keep history.
+type CentrifugoTokens struct {
+	secret   []byte
+	ttl      time.Duration
+	recovery bool
+}
+
+type centrifugoSubscriptionClaims struct {
+	Channel  string                     `json:"channel"`
+	Override *centrifugoOptionsOverride `json:"override,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// centrifugoOptionsOverride overrides the channel options of a subscription.
+type centrifugoOptionsOverride struct {
+	ForcePositioning *centrifugoBoolValue `json:"force_positioning,omitempty"`
+	ForceRecovery    *centrifugoBoolValue `json:"force_recovery,omitempty"`
+}
+
+type centrifugoBoolValue struct {
+	Value bool `json:"value"`
+}
+
+func NewCentrifugoTokens(secret string, ttl time.Duration, recovery bool) *CentrifugoTokens {
+	return &CentrifugoTokens{
+		secret:   []byte(secret),
+		ttl:      ttl,
+		recovery: recovery,
+	}
+}
+
+func (t *CentrifugoTokens) ConnectionToken(userID uint) (string, time.Time, error) {
+	claims := t.registeredClaims(userID)
+	return t.sign(claims, claims.ExpiresAt.Time)
+}
+
+func (t *CentrifugoTokens) SubscriptionToken(userID uint, channel string) (string, time.Time, error) {
+	claims := centrifugoSubscriptionClaims{
+		Channel:          channel,
+		RegisteredClaims: t.registeredClaims(userID),
+	}
+	if t.recovery {
+		claims.Override = &centrifugoOptionsOverride{
+			ForcePositioning: &centrifugoBoolValue{Value: true},
+			ForceRecovery:    &centrifugoBoolValue{Value: true},
+		}
+	}
+	return t.sign(claims, claims.ExpiresAt.Time)
+}
+
+func (t *CentrifugoTokens) registeredClaims(userID uint) jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Subject:   strconv.FormatUint(uint64(userID), 10),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(t.ttl)),
+	}
+}
+
+func (t *CentrifugoTokens) sign(claims jwt.Claims, expiresAt time.Time) (string, time.Time, error) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(t.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign centrifugo token: %v", err)
+	}
+	return token, expiresAt, nil
+}
@@ -3,32 +3,116 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/VaheMuradyan/Sport/config"
 	"github.com/centrifugal/centrifuge-go"
 )
 
 type CoefficientUpdate struct {
 	Type           string    `json:"type"`
 	MarketID       uint      `json:"market_id"`
+	SelectionID    uint      `json:"selection_id"`
+	Selection      string    `json:"selection"`
+	Line           *float64  `json:"line"`
 	EventID        uint      `json:"event_id"`
+	CompetitionID  uint      `json:"competition_id"`
 	OldCoefficient float64   `json:"old_coefficient"`
 	NewCoefficient float64   `json:"new_coefficient"`
 	Timestamp      time.Time `json:"timestamp"`
 }
 
+type EventStatus struct {
+	Type           string `json:"type"`
+	EventID        uint   `json:"event_id"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason"`
+}
+
+type Incident struct {
+	Type         string `json:"type"`
+	EventID      uint   `json:"event_id"`
+	IncidentType string `json:"incident_type"`
+	TeamName     string `json:"team_name"`
+	Period       string `json:"period"`
+	Minute       int    `json:"minute"`
+	Player       string `json:"player"`
+	Detail       string `json:"detail"`
+	Score        []struct {
+		Name  string `json:"name"`
+		Goals int    `json:"goals"`
+	} `json:"score"`
+}
+
+type MarketStatus struct {
+	Type           string `json:"type"`
+	MarketID       uint   `json:"market_id"`
+	EventID        uint   `json:"event_id"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason"`
+}
+
+// CoefficientBatchUpdate holds market updates of one event applied together.
+type MarketLine struct {
+	Type         string              `json:"type"`
+	MarketID     uint                `json:"market_id"`
+	EventID      uint                `json:"event_id"`
+	PreviousLine *float64            `json:"previous_line"`
+	Line         float64             `json:"line"`
+	Updates      []CoefficientUpdate `json:"updates"`
+	Sequence     uint64              `json:"sequence"`
+}
+
+type CoefficientBatchUpdate struct {
+	Type     string              `json:"type"`
+	EventID  uint                `json:"event_id"`
+	Updates  []CoefficientUpdate `json:"updates"`
+	Sequence uint64              `json:"sequence"`
+}
+
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [channel ...]\n\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Channels: odds:event:<id>, odds:market:<id>, odds:competition:<id> or odds_updates.")
+		fmt.Fprintln(flag.CommandLine.Output(), "Without arguments the channels from client.channels are used.")
+		flag.PrintDefaults()
+	}
+
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if args := flag.Args(); len(args) > 0 {
+		cfg.Client.Channels = args
+	}
+	if err := cfg.ValidateClient(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	channels := cfg.Client.Channels
+
 	log.Println("🚀 Starting Centrifugo WebSocket client...")
 
-	// Create client with anonymous connection (no token needed)
+	// Connection and subscription tokens are fetched from the Sport API and
+	// refreshed by centrifuge-go through the GetToken callbacks.
+	tokens := newTokenSource(cfg.Client.APIURL, cfg.Client.Username, cfg.Client.Password)
+
+	// Centrifugo recovers the publications missed while reconnecting from
+	// channel history. If it can't, the updates are replayed from the API.
+	history := newReplayer(cfg.Client.APIURL)
+
 	client := centrifuge.NewProtobufClient(
-		"ws://localhost:8000/connection/websocket",
+		cfg.Centrifugo.WebsocketURL,
 		centrifuge.Config{
-			// No token needed for anonymous connection
+			GetToken: tokens.connectionToken,
 		},
 	)
 
@@ -50,7 +134,7 @@ func main() {
 	})
 
 	// Connect to Centrifugo
-	err := client.Connect()
+	err = client.Connect()
 	if err != nil {
 		log.Fatalf("Failed to connect: %v", err)
 	}
@@ -59,16 +143,15 @@ func main() {
 	// Wait a moment for connection to establish
 	time.Sleep(1 * time.Second)
 
-	// Subscribe to ONLY ONE channel to avoid permission issues
-	channels := []string{
-		"odds_updates", // Only subscribe to the global channel
-	}
-
 	var subscriptions []*centrifuge.Subscription
 
 	// Subscribe to each channel
 	for _, channelName := range channels {
-		sub, err := client.NewSubscription(channelName)
+		sub, err := client.NewSubscription(channelName, centrifuge.SubscriptionConfig{
+			GetToken:    tokens.subscriptionToken,
+			Positioned:  true,
+			Recoverable: true,
+		})
 		if err != nil {
 			log.Printf("❌ Failed to create subscription for %s: %v", channelName, err)
 			continue
@@ -77,6 +160,23 @@ func main() {
 		// Set up subscription event handlers
 		sub.OnSubscribed(func(e centrifuge.SubscribedEvent) {
 			log.Printf("📡 Successfully subscribed to: %s", channelName)
+			if !e.WasRecovering {
+				return
+			}
+			if e.Recovered {
+				log.Printf("♻️ Recovered missed updates for: %s", channelName)
+				return
+			}
+
+			log.Printf("⚠️ Could not recover %s from Centrifugo, replaying from the API", channelName)
+			replayed, err := history.replay(channelName, func(data []byte) {
+				handleMessage(channelName, data)
+			})
+			if err != nil {
+				log.Printf("❌ Failed to replay history for %s: %v", channelName, err)
+				return
+			}
+			log.Printf("♻️ Replayed %d updates for: %s", replayed, channelName)
 		})
 
 		sub.OnUnsubscribed(func(e centrifuge.UnsubscribedEvent) {
@@ -84,6 +184,7 @@ func main() {
 		})
 
 		sub.OnPublication(func(e centrifuge.PublicationEvent) {
+			history.observe(channelName, e.Data)
 			handleMessage(channelName, e.Data)
 		})
 
@@ -133,6 +234,55 @@ func handleMessage(channel string, data []byte) {
 		return
 	}
 
+	var batch CoefficientBatchUpdate
+	if err := json.Unmarshal(data, &batch); err == nil && batch.Type == "coefficient_batch_update" {
+		log.Printf("📦 [%s] BATCH UPDATE of %d markets | Event: %d | Sequence: %d",
+			channel, len(batch.Updates), batch.EventID, batch.Sequence)
+		for _, update := range batch.Updates {
+			printCoefficientUpdate(channel, update)
+		}
+		return
+	}
+
+	var marketLine MarketLine
+	if err := json.Unmarshal(data, &marketLine); err == nil && marketLine.Type == "market_line" {
+		previous := "none"
+		if marketLine.PreviousLine != nil {
+			previous = fmt.Sprintf("%.2f", *marketLine.PreviousLine)
+		}
+		log.Printf("📏 [%s] LINE MOVE market %d %s → %.2f | Event: %d | Sequence: %d",
+			channel, marketLine.MarketID, previous, marketLine.Line, marketLine.EventID, marketLine.Sequence)
+		for _, update := range marketLine.Updates {
+			printCoefficientUpdate(channel, update)
+		}
+		return
+	}
+
+	var incident Incident
+	if err := json.Unmarshal(data, &incident); err == nil && incident.Type == "incident" {
+		score := make([]string, len(incident.Score))
+		for i, team := range incident.Score {
+			score[i] = fmt.Sprintf("%s %d", team.Name, team.Goals)
+		}
+		log.Printf("⚽ [%s] EVENT %d %s %s %d' %s %s | %s", channel, incident.EventID, incident.IncidentType,
+			incident.TeamName, incident.Minute, incident.Player, incident.Detail, strings.Join(score, " - "))
+		return
+	}
+
+	var eventStatus EventStatus
+	if err := json.Unmarshal(data, &eventStatus); err == nil && eventStatus.Type == "event_status" {
+		log.Printf("🏟️ [%s] EVENT %d %s → %s %s", channel, eventStatus.EventID,
+			eventStatus.PreviousStatus, eventStatus.Status, eventStatus.Reason)
+		return
+	}
+
+	var marketStatus MarketStatus
+	if err := json.Unmarshal(data, &marketStatus); err == nil && marketStatus.Type == "market_status" {
+		log.Printf("🚦 [%s] MARKET %d %s → %s | Event: %d %s", channel, marketStatus.MarketID,
+			marketStatus.PreviousStatus, marketStatus.Status, marketStatus.EventID, marketStatus.Reason)
+		return
+	}
+
 	// Try to parse as generic JSON for pretty printing
 	var jsonData map[string]interface{}
 	if err := json.Unmarshal(data, &jsonData); err == nil {
@@ -158,7 +308,11 @@ func printCoefficientUpdate(channel string, update CoefficientUpdate) {
 	}
 
 	log.Printf("🎯 [%s] COEFFICIENT UPDATE:", channel)
-	log.Printf("   Market: %d | Event: %d", update.MarketID, update.EventID)
+	log.Printf("   Market: %d | Selection: %d %s | Event: %d | Competition: %d",
+		update.MarketID, update.SelectionID, update.Selection, update.EventID, update.CompetitionID)
+	if update.Line != nil {
+		log.Printf("   Line: %.2f", *update.Line)
+	}
 	log.Printf("   %.2f → %.2f %s (%.2f%% change)",
 		update.OldCoefficient, update.NewCoefficient, direction, changePercent)
 	log.Printf("   Time: %s", update.Timestamp.Format("15:04:05"))
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// replayer remembers when each channel last received an update and, when
+// Centrifugo could not recover a subscription, fetches what was missed from
+// the Sport API history endpoint.
+type replayer struct {
+	apiURL string
+	client *http.Client
+
+	mu       sync.Mutex
+	lastSeen map[string]time.Time
+}
+
+type historyResponse struct {
+	Updates    []json.RawMessage `json:"updates"`
+	NextOffset uint              `json:"next_offset"`
+	HasMore    bool              `json:"has_more"`
+}
+
+func newReplayer(apiURL string) *replayer {
+	return &replayer{
+		apiURL: apiURL,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		lastSeen: make(map[string]time.Time),
+	}
+}
+
+// observe records the timestamp of an update received on the channel.
+func (r *replayer) observe(channel string, data []byte) {
+	var update struct {
+		Timestamp time.Time `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &update); err != nil || update.Timestamp.IsZero() {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if update.Timestamp.After(r.lastSeen[channel]) {
+		r.lastSeen[channel] = update.Timestamp
+	}
+}
+
+// replay passes every update of the channel since the last one observed to
+// handle. Updates at the last observed time are replayed again.
+func (r *replayer) replay(channel string, handle func(data []byte)) (int, error) {
+	r.mu.Lock()
+	since, ok := r.lastSeen[channel]
+	r.mu.Unlock()
+	if !ok {
+		return 0, nil
+	}
+
+	params := url.Values{"channel": {channel}, "since": {since.Format(time.RFC3339Nano)}}
+	replayed := 0
+	for {
+		var page historyResponse
+		if err := r.get(params, &page); err != nil {
+			return replayed, err
+		}
+		for _, update := range page.Updates {
+			r.observe(channel, update)
+			handle(update)
+		}
+		replayed += len(page.Updates)
+
+		if !page.HasMore {
+			return replayed, nil
+		}
+		params = url.Values{"channel": {channel}, "offset": {strconv.FormatUint(uint64(page.NextOffset), 10)}}
+	}
+}
+
+func (r *replayer) get(params url.Values, out interface{}) error {
+	resp, err := r.client.Get(r.apiURL + "/api/v1/history?" + params.Encode())
+	if err != nil {
+		return fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("history request failed with status code: %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	return nil
+}
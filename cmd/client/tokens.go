@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/centrifugal/centrifuge-go"
+)
+
+// tokenSource logs in to the Sport API and exchanges the resulting access
+// token for Centrifugo connection and subscription tokens.
+type tokenSource struct {
+	apiURL   string
+	username string
+	password string
+	client   *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+}
+
+type tokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func newTokenSource(apiURL, username, password string) *tokenSource {
+	return &tokenSource{
+		apiURL:   apiURL,
+		username: username,
+		password: password,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// connectionToken is the centrifuge-go GetToken callback for the client.
+func (t *tokenSource) connectionToken(e centrifuge.ConnectionTokenEvent) (string, error) {
+	return t.fetch("/api/v1/centrifugo/connection-token", nil)
+}
+
+// subscriptionToken is the centrifuge-go GetToken callback for subscriptions.
+func (t *tokenSource) subscriptionToken(e centrifuge.SubscriptionTokenEvent) (string, error) {
+	return t.fetch("/api/v1/centrifugo/subscription-token", map[string]string{"channel": e.Channel})
+}
+
+// fetch requests a Centrifugo token, logging in again once if the access
+// token has expired.
+func (t *tokenSource) fetch(path string, body interface{}) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for attempt := 0; attempt < 2; attempt++ {
+		if t.accessToken == "" {
+			if err := t.login(); err != nil {
+				return "", err
+			}
+		}
+
+		var token tokenResponse
+		status, err := t.post(path, body, t.accessToken, &token)
+		if err != nil {
+			return "", err
+		}
+		switch status {
+		case http.StatusOK:
+			return token.Token, nil
+		case http.StatusUnauthorized:
+			t.accessToken = ""
+		case http.StatusForbidden:
+			return "", centrifuge.ErrUnauthorized
+		default:
+			return "", fmt.Errorf("token request to %s failed with status code: %d", path, status)
+		}
+	}
+
+	return "", centrifuge.ErrUnauthorized
+}
+
+func (t *tokenSource) login() error {
+	var login tokenResponse
+	status, err := t.post("/api/v1/auth/login", map[string]string{
+		"username": t.username,
+		"password": t.password,
+	}, "", &login)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("login failed with status code: %d", status)
+	}
+
+	t.accessToken = login.Token
+	return nil
+}
+
+func (t *tokenSource) post(path string, body interface{}, accessToken string, out interface{}) (int, error) {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest("POST", t.apiURL+path, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return 0, fmt.Errorf("failed to decode response: %v", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
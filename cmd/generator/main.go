@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	"github.com/VaheMuradyan/Sport/config"
 	"github.com/VaheMuradyan/Sport/generator"
 	"log"
 	"os"
@@ -12,8 +14,17 @@ import (
 func main() {
 	log.Println("🚀 Starting Odds Generator...")
 
-	// Create odds generator
-	gen, err := generator.NewCoefficientGenerator("localhost:50051")
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if err := cfg.ValidateGenerator(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	// Create odds generator. It trades as the feed bot service account the
+	// server provisions from the same generator settings.
+	gen, err := generator.NewCoefficientGenerator(cfg.Generator.GRPCTarget, cfg.Generator.APIURL, cfg.Generator.Username, cfg.Generator.Password)
 	if err != nil {
 		log.Fatalf("Failed to create odds generator: %v", err)
 	}
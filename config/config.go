@@ -0,0 +1,460 @@
+// Package config loads the settings of every Sport binary.
+//
+// Values are resolved in this order, later sources overriding earlier ones:
+// built-in defaults, the YAML file named by -config or SPORT_CONFIG,
+// environment variables and command line flags. Every setting has an
+// environment variable and a flag derived from its YAML path, e.g.
+// centrifugo.api_key is SPORT_CENTRIFUGO_API_KEY and -centrifugo.api_key.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"slices"
+	"time"
+)
+
+type Config struct {
+	Database   DatabaseConfig   `yaml:"database"`
+	Server     ServerConfig     `yaml:"server"`
+	Auth       AuthConfig       `yaml:"auth"`
+	Centrifugo CentrifugoConfig `yaml:"centrifugo"`
+	Publisher  PublisherConfig  `yaml:"publisher"`
+	Outbox     OutboxConfig     `yaml:"outbox"`
+	Audience   AudienceConfig   `yaml:"audience"`
+	Generator  GeneratorConfig  `yaml:"generator"`
+	Client     ClientConfig     `yaml:"client"`
+}
+
+type DatabaseConfig struct {
+	DSN string `yaml:"dsn"`
+}
+
+type ServerConfig struct {
+	HTTPAddr string `yaml:"http_addr"`
+	GRPCAddr string `yaml:"grpc_addr"`
+	// IngestWindow is how many requests of an IngestCoefficients stream are
+	// read ahead of the one being applied.
+	IngestWindow int `yaml:"ingest_window"`
+}
+
+// AuthConfig configures tokens and the accounts provisioned at startup.
+// Provisioned accounts are created when missing; with ResetAccounts set their
+// password and role are also restored on every start.
+type AuthConfig struct {
+	JWTSecret     string        `yaml:"jwt_secret"`
+	TokenTTL      time.Duration `yaml:"token_ttl"`
+	AdminUsername string        `yaml:"admin_username"`
+	AdminPassword string        `yaml:"admin_password"`
+	ResetAccounts bool          `yaml:"reset_accounts"`
+}
+
+// CentrifugoConfig configures the Centrifugo server API, reached over
+// APITransport, and the client tokens. APIVersion selects the HTTP API of
+// Centrifugo v5 and later or the single /api endpoint of v4.
+type CentrifugoConfig struct {
+	APITransport string                  `yaml:"api_transport"`
+	APIVersion   string                  `yaml:"api_version"`
+	APIURL       string                  `yaml:"api_url"`
+	GRPCAddr     string                  `yaml:"grpc_addr"`
+	GRPCTLS      bool                    `yaml:"grpc_tls"`
+	APIKey       string                  `yaml:"api_key"`
+	WebsocketURL string                  `yaml:"websocket_url"`
+	TokenSecret  string                  `yaml:"token_secret"`
+	TokenTTL     time.Duration           `yaml:"token_ttl"`
+	Batch        CentrifugoBatchConfig   `yaml:"batch"`
+	History      CentrifugoHistoryConfig `yaml:"history"`
+}
+
+// CentrifugoHistoryConfig describes the history kept for odds channels. Size
+// and TTL must match history_size and history_ttl of the "odds" namespace and
+// the top level channel options in Centrifugo (see centrifugo.example.json).
+// When enabled, subscription tokens turn on positioning and recovery so
+// clients get the publications they missed while reconnecting.
+type CentrifugoHistoryConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Size    int           `yaml:"size"`
+	TTL     time.Duration `yaml:"ttl"`
+}
+
+// Centrifugo API transports.
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// Centrifugo server API versions.
+const (
+	CentrifugoAPIV4 = "v4"
+	CentrifugoAPIV5 = "v5"
+)
+
+// CentrifugoBatchConfig controls batching of publications through the
+// Centrifugo batch API. A batch is sent after Window or once it holds
+// MaxSize publications, and may mix outbox deliveries with other updates.
+type CentrifugoBatchConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Window  time.Duration `yaml:"window"`
+	MaxSize int           `yaml:"max_size"`
+}
+
+// Publisher backends.
+const (
+	BackendCentrifugo = "centrifugo"
+	BackendMemory     = "memory"
+	BackendNATS       = "nats"
+	BackendRedis      = "redis"
+	BackendKafka      = "kafka"
+	BackendWebhook    = "webhook"
+)
+
+// globalOddsChannel is services.GlobalOddsChannel.
+const globalOddsChannel = "odds_updates"
+
+// PublisherConfig selects where coefficient updates are published. Listing
+// several backends fans every update out to all of them. With PublishGlobal
+// set every update also goes to the global odds channel.
+type PublisherConfig struct {
+	Backends      []string      `yaml:"backends"`
+	PublishGlobal bool          `yaml:"publish_global"`
+	NATS          NATSConfig    `yaml:"nats"`
+	Redis         RedisConfig   `yaml:"redis"`
+	Kafka         KafkaConfig   `yaml:"kafka"`
+	Webhook       WebhookConfig `yaml:"webhook"`
+}
+
+type NATSConfig struct {
+	URL           string `yaml:"url"`
+	SubjectPrefix string `yaml:"subject_prefix"`
+}
+
+type RedisConfig struct {
+	Addr          string `yaml:"addr"`
+	Password      string `yaml:"password"`
+	DB            int    `yaml:"db"`
+	ChannelPrefix string `yaml:"channel_prefix"`
+}
+
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+}
+
+// WebhookConfig configures the webhook backend. With Secret set every
+// request is signed with an HMAC-SHA256 of its body.
+type WebhookConfig struct {
+	URL     string        `yaml:"url"`
+	Secret  string        `yaml:"secret"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// OutboxConfig controls delivery of the Centrifugo outbox. A message is
+// retried with exponential backoff from InitialBackoff up to MaxBackoff and
+// dead-lettered after MaxAttempts failures. Delivered messages are deleted
+// after Retention. Every instance runs a dispatcher; a dispatcher claims its
+// batch for Lease, after which a batch it did not finish is delivered by
+// another.
+type OutboxConfig struct {
+	PollInterval   time.Duration `yaml:"poll_interval"`
+	BatchSize      int           `yaml:"batch_size"`
+	MaxAttempts    int           `yaml:"max_attempts"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+	Retention      time.Duration `yaml:"retention"`
+	Lease          time.Duration `yaml:"lease"`
+}
+
+// AudienceConfig controls sampling of Centrifugo presence stats for event and
+// market channels. Every Interval the events that are live or start within
+// Lookahead are sampled. Samples are deleted after Retention.
+type AudienceConfig struct {
+	Enabled   bool          `yaml:"enabled"`
+	Interval  time.Duration `yaml:"interval"`
+	Lookahead time.Duration `yaml:"lookahead"`
+	Retention time.Duration `yaml:"retention"`
+}
+
+// GeneratorConfig holds the odds generator's connection and the service
+// account it trades as. The server provisions that account from the same
+// settings.
+type GeneratorConfig struct {
+	GRPCTarget string `yaml:"grpc_target"`
+	APIURL     string `yaml:"api_url"`
+	Username   string `yaml:"username"`
+	Password   string `yaml:"password"`
+	SportID    uint   `yaml:"sport_id"`
+}
+
+type ClientConfig struct {
+	APIURL   string   `yaml:"api_url"`
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	Channels []string `yaml:"channels"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			HTTPAddr:     ":8080",
+			GRPCAddr:     ":50051",
+			IngestWindow: 256,
+		},
+		Auth: AuthConfig{
+			TokenTTL: time.Hour,
+		},
+		Centrifugo: CentrifugoConfig{
+			APITransport: TransportHTTP,
+			APIVersion:   CentrifugoAPIV5,
+			APIURL:       "http://localhost:8000",
+			GRPCAddr:     "localhost:10000",
+			WebsocketURL: "ws://localhost:8000/connection/websocket",
+			TokenTTL:     15 * time.Minute,
+			Batch: CentrifugoBatchConfig{
+				Enabled: true,
+				Window:  10 * time.Millisecond,
+				MaxSize: 100,
+			},
+			History: CentrifugoHistoryConfig{
+				Enabled: true,
+				Size:    300,
+				TTL:     10 * time.Minute,
+			},
+		},
+		Publisher: PublisherConfig{
+			Backends:      []string{BackendCentrifugo},
+			PublishGlobal: true,
+			NATS: NATSConfig{
+				URL: "nats://localhost:4222",
+			},
+			Redis: RedisConfig{
+				Addr: "localhost:6379",
+			},
+			Kafka: KafkaConfig{
+				Brokers: []string{"localhost:9092"},
+				Topic:   "odds",
+			},
+			Webhook: WebhookConfig{
+				Timeout: 10 * time.Second,
+			},
+		},
+		Outbox: OutboxConfig{
+			PollInterval:   time.Second,
+			BatchSize:      100,
+			MaxAttempts:    10,
+			InitialBackoff: time.Second,
+			MaxBackoff:     5 * time.Minute,
+			Retention:      24 * time.Hour,
+			Lease:          time.Minute,
+		},
+		Audience: AudienceConfig{
+			Enabled:   true,
+			Interval:  time.Minute,
+			Lookahead: 24 * time.Hour,
+			Retention: 30 * 24 * time.Hour,
+		},
+		Generator: GeneratorConfig{
+			GRPCTarget: "localhost:50051",
+			APIURL:     "http://localhost:8080",
+		},
+		Client: ClientConfig{
+			APIURL:   "http://localhost:8080",
+			Channels: []string{globalOddsChannel},
+		},
+	}
+}
+
+// Load resolves the configuration from defaults, file, environment and the
+// flags in args. It registers its flags on fs, so callers can add their own
+// before calling Load and read fs.Args() afterwards.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	cfg := Default()
+
+	configPath := fs.String("config", os.Getenv("SPORT_CONFIG"), "path to a YAML configuration file")
+	settings := registerFlags(fs, cfg)
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *configPath != "" {
+		if err := loadFile(cfg, *configPath); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env); ok {
+			if err := s.set(value); err != nil {
+				return nil, fmt.Errorf("invalid %s: %v", s.env, err)
+			}
+		}
+	}
+
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		if s, ok := settings[f.Name]; ok && flagErr == nil {
+			if err := s.set(f.Value.String()); err != nil {
+				flagErr = fmt.Errorf("invalid -%s: %v", f.Name, err)
+			}
+		}
+	})
+	if flagErr != nil {
+		return nil, flagErr
+	}
+
+	return cfg, nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %v", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+	return nil
+}
+
+// ValidateServer checks the settings the API server needs.
+func (c *Config) ValidateServer() error {
+	var errs []error
+	require := func(value, name string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", name))
+		}
+	}
+
+	require(c.Database.DSN, "database.dsn")
+	require(c.Server.HTTPAddr, "server.http_addr")
+	require(c.Server.GRPCAddr, "server.grpc_addr")
+	if c.Server.IngestWindow < 1 {
+		errs = append(errs, errors.New("server.ingest_window must be positive"))
+	}
+	require(c.Auth.JWTSecret, "auth.jwt_secret")
+	require(c.Centrifugo.TokenSecret, "centrifugo.token_secret")
+
+	if len(c.Publisher.Backends) == 0 {
+		errs = append(errs, errors.New("publisher.backends must not be empty"))
+	}
+	seen := make(map[string]bool)
+	for _, backend := range c.Publisher.Backends {
+		if seen[backend] {
+			errs = append(errs, fmt.Errorf("publisher backend %q is listed twice", backend))
+			continue
+		}
+		seen[backend] = true
+
+		switch backend {
+		case BackendCentrifugo:
+			switch c.Centrifugo.APITransport {
+			case TransportHTTP:
+				require(c.Centrifugo.APIURL, "centrifugo.api_url")
+			case TransportGRPC:
+				require(c.Centrifugo.GRPCAddr, "centrifugo.grpc_addr")
+				if c.Centrifugo.APIVersion == CentrifugoAPIV4 {
+					errs = append(errs, errors.New("centrifugo.api_transport grpc needs centrifugo.api_version v5"))
+				}
+			default:
+				errs = append(errs, fmt.Errorf("unknown centrifugo.api_transport %q", c.Centrifugo.APITransport))
+			}
+			if c.Centrifugo.APIVersion != CentrifugoAPIV4 && c.Centrifugo.APIVersion != CentrifugoAPIV5 {
+				errs = append(errs, fmt.Errorf("unknown centrifugo.api_version %q", c.Centrifugo.APIVersion))
+			}
+			require(c.Centrifugo.APIKey, "centrifugo.api_key")
+			if c.Centrifugo.Batch.Enabled && (c.Centrifugo.Batch.Window <= 0 || c.Centrifugo.Batch.MaxSize <= 0) {
+				errs = append(errs, errors.New("centrifugo.batch.window and centrifugo.batch.max_size must be positive"))
+			}
+		case BackendMemory:
+		case BackendNATS:
+			require(c.Publisher.NATS.URL, "publisher.nats.url")
+		case BackendRedis:
+			require(c.Publisher.Redis.Addr, "publisher.redis.addr")
+		case BackendKafka:
+			if len(c.Publisher.Kafka.Brokers) == 0 {
+				errs = append(errs, errors.New("publisher.kafka.brokers must not be empty"))
+			}
+			require(c.Publisher.Kafka.Topic, "publisher.kafka.topic")
+		case BackendWebhook:
+			require(c.Publisher.Webhook.URL, "publisher.webhook.url")
+			if c.Publisher.Webhook.Timeout <= 0 {
+				errs = append(errs, errors.New("publisher.webhook.timeout must be positive"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("unknown publisher backend %q", backend))
+		}
+	}
+
+	if c.Auth.TokenTTL <= 0 {
+		errs = append(errs, errors.New("auth.token_ttl must be positive"))
+	}
+	if c.Centrifugo.History.Enabled && (c.Centrifugo.History.Size <= 0 || c.Centrifugo.History.TTL <= 0) {
+		errs = append(errs, errors.New("centrifugo.history.size and centrifugo.history.ttl must be positive"))
+	}
+	if c.Centrifugo.TokenTTL <= 0 {
+		errs = append(errs, errors.New("centrifugo.token_ttl must be positive"))
+	}
+	if c.Outbox.PollInterval <= 0 || c.Outbox.InitialBackoff <= 0 || c.Outbox.Retention <= 0 || c.Outbox.Lease <= 0 {
+		errs = append(errs, errors.New("outbox.poll_interval, outbox.initial_backoff, outbox.retention and outbox.lease must be positive"))
+	}
+	if c.Outbox.MaxBackoff < c.Outbox.InitialBackoff {
+		errs = append(errs, errors.New("outbox.max_backoff must not be less than outbox.initial_backoff"))
+	}
+	if c.Outbox.BatchSize <= 0 || c.Outbox.MaxAttempts <= 0 {
+		errs = append(errs, errors.New("outbox.batch_size and outbox.max_attempts must be positive"))
+	}
+	if c.Audience.Enabled && (c.Audience.Interval <= 0 || c.Audience.Lookahead < 0 || c.Audience.Retention <= 0) {
+		errs = append(errs, errors.New("audience.interval and audience.retention must be positive and audience.lookahead must not be negative"))
+	}
+	if (c.Auth.AdminUsername == "") != (c.Auth.AdminPassword == "") {
+		errs = append(errs, errors.New("auth.admin_username and auth.admin_password must be set together"))
+	}
+	if c.Generator.Username != "" && (c.Generator.Password == "" || c.Generator.SportID == 0) {
+		errs = append(errs, errors.New("generator.password and generator.sport_id are required to provision generator.username"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateGenerator checks the settings the odds generator needs.
+func (c *Config) ValidateGenerator() error {
+	var errs []error
+	if c.Generator.GRPCTarget == "" {
+		errs = append(errs, errors.New("generator.grpc_target is required"))
+	}
+	if c.Generator.APIURL == "" {
+		errs = append(errs, errors.New("generator.api_url is required"))
+	}
+	if c.Generator.Username == "" || c.Generator.Password == "" {
+		errs = append(errs, errors.New("generator.username and generator.password are required"))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateClient checks the settings the Centrifugo client needs.
+func (c *Config) ValidateClient() error {
+	var errs []error
+	if c.Centrifugo.WebsocketURL == "" {
+		errs = append(errs, errors.New("centrifugo.websocket_url is required"))
+	}
+	if c.Client.APIURL == "" {
+		errs = append(errs, errors.New("client.api_url is required"))
+	}
+	if c.Client.Username == "" || c.Client.Password == "" {
+		errs = append(errs, errors.New("client.username and client.password are required"))
+	}
+	if len(c.Client.Channels) == 0 {
+		errs = append(errs, errors.New("client.channels must not be empty"))
+	}
+	if slices.Contains(c.Client.Channels, globalOddsChannel) && !c.Publisher.PublishGlobal {
+		errs = append(errs, fmt.Errorf("client.channels has %s but publisher.publish_global is off, nothing is published to it", globalOddsChannel))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting is a single leaf value of Config, addressed by its YAML path.
+type setting struct {
+	env   string
+	field reflect.Value
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func registerFlags(fs *flag.FlagSet, cfg *Config) map[string]*setting {
+	settings := make(map[string]*setting)
+	collectSettings(reflect.ValueOf(cfg).Elem(), "", settings)
+
+	for name, s := range settings {
+		fs.String(name, s.String(), fmt.Sprintf("overrides %s (env %s)", name, s.env))
+	}
+	return settings
+}
+
+func collectSettings(v reflect.Value, prefix string, settings map[string]*setting) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			collectSettings(field, name, settings)
+			continue
+		}
+
+		settings[name] = &setting{
+			env:   "SPORT_" + strings.ToUpper(strings.ReplaceAll(name, ".", "_")),
+			field: field,
+		}
+	}
+}
+
+func (s *setting) String() string {
+	switch {
+	case s.field.Type() == durationType:
+		return time.Duration(s.field.Int()).String()
+	case s.field.Kind() == reflect.Slice:
+		return strings.Join(s.field.Interface().([]string), ",")
+	default:
+		return fmt.Sprint(s.field.Interface())
+	}
+}
+
+func (s *setting) set(value string) error {
+	switch {
+	case s.field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		s.field.SetInt(int64(d))
+	case s.field.Kind() == reflect.String:
+		s.field.SetString(value)
+	case s.field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		s.field.SetBool(b)
+	case s.field.Kind() == reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		s.field.SetInt(int64(n))
+	case s.field.Kind() == reflect.Uint:
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		s.field.SetUint(n)
+	case s.field.Kind() == reflect.Slice && s.field.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		s.field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported setting type %s", s.field.Type())
+	}
+	return nil
+}
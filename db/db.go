@@ -1,6 +1,7 @@
 package db
 
 import (
+	"github.com/VaheMuradyan/Sport/auth"
 	"github.com/VaheMuradyan/Sport/models"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -10,7 +11,7 @@ import (
 	"time"
 )
 
-func ConnectDB() *gorm.DB {
+func ConnectDB(dsn string) *gorm.DB {
 	var DB *gorm.DB
 	var err error
 	newLogger := logger.New(
@@ -22,13 +23,162 @@ func ConnectDB() *gorm.DB {
 			Colorful:                  true,
 		},
 	)
-	dsn := "vahe:java@tcp(127.0.0.1:3306)/sport?charset=utf8mb4&parseTime=True&loc=Local"
 	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: newLogger})
 
 	if err != nil {
 		panic("Failed to connect to databse!")
 	}
 
-	DB.AutoMigrate(&models.User{}, &models.Market{}, &models.Country{}, &models.Competition{}, &models.Event{}, &models.Team{}, &models.CoefficientHistory{})
+	DB.AutoMigrate(&models.User{}, &models.Sport{}, &models.Market{}, &models.Selection{}, &models.Country{}, &models.Competition{}, &models.Event{}, &models.Team{}, &models.CoefficientHistory{}, &models.TradingPermission{}, &models.AuditLog{}, &models.OutboxMessage{}, &models.AudienceSample{}, &models.Incident{}, &models.EventPeriod{}, &models.Score{}, &models.LineHistory{}, &models.MarketTemplate{}, &models.SelectionTemplate{})
+	hashPlaintextPasswords(DB)
+	migrateMarketStatus(DB)
+	migrateEventStatus(DB)
+	migrateSelections(DB)
 	return DB
 }
+
+// migrateMarketStatus suspends the markets that were deactivated before
+// markets had a status.
+func migrateMarketStatus(DB *gorm.DB) {
+	err := DB.Model(&models.Market{}).
+		Where("active = ? AND status = ?", false, models.MarketOpen).
+		Update("status", models.MarketSuspended).Error
+	if err != nil {
+		log.Printf("Failed to migrate market statuses: %v", err)
+	}
+}
+
+// hashPlaintextPasswords replaces passwords stored before hashing was
+// introduced with their bcrypt hash.
+func hashPlaintextPasswords(DB *gorm.DB) {
+	var users []models.User
+	if err := DB.Find(&users).Error; err != nil {
+		log.Printf("Failed to load users for password migration: %v", err)
+		return
+	}
+
+	for _, user := range users {
+		if user.PasswordHash == "" || auth.IsPasswordHash(user.PasswordHash) {
+			continue
+		}
+		hash, err := auth.HashPassword(user.PasswordHash)
+		if err != nil {
+			log.Printf("Failed to hash password of user %d: %v", user.ID, err)
+			continue
+		}
+		if err := DB.Model(&user).Update("password", hash).Error; err != nil {
+			log.Printf("Failed to store hashed password of user %d: %v", user.ID, err)
+		}
+	}
+}
+
+// migrateEventStatus maps free-form event statuses from before the event
+// lifecycle to live for live events and to scheduled otherwise.
+func migrateEventStatus(DB *gorm.DB) {
+	known := []string{models.EventScheduled, models.EventPreMatch, models.EventLive, models.EventHalfTime,
+		models.EventFinished, models.EventPostponed, models.EventCancelled}
+
+	err := DB.Model(&models.Event{}).
+		Where("status NOT IN ? OR status IS NULL", known).
+		Update("status", gorm.Expr("CASE WHEN is_live THEN ? ELSE ? END", models.EventLive, models.EventScheduled)).Error
+	if err != nil {
+		log.Printf("Failed to migrate event statuses: %v", err)
+	}
+}
+
+// marketOddsColumns are the odds columns of markets from before selections.
+var marketOddsColumns = []string{"current_coefficient", "previous_coefficient", "min_coefficient", "max_coefficient", "last_updated"}
+
+// legacyMarketNames names the markets that group the outcomes of a type.
+var legacyMarketNames = map[string]string{
+	models.MarketMatchWinner: "Match Winner",
+	models.MarketOverUnder:   "Over/Under",
+	models.MarketHandicap:    "Handicap",
+}
+
+// migrateSelections moves the odds of markets from before selections into a
+// selection of the same name and points their coefficient history at it. The
+// odds columns are dropped once they are copied.
+//
+// Before selections every outcome was a market of its own, so the markets of
+// one event and type, such as the win, draw and loss of a match winner, become
+// one market: the first of them keeps the selections and history of all and
+// the others are deleted.
+func migrateSelections(DB *gorm.DB) {
+	if !DB.Migrator().HasColumn(&models.Market{}, "current_coefficient") {
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`INSERT INTO selections (created_at, updated_at, deleted_at, market_id, name,
+				current_coefficient, previous_coefficient, min_coefficient, max_coefficient, last_updated, version)
+			SELECT created_at, updated_at, deleted_at, id, name,
+				current_coefficient, previous_coefficient, min_coefficient, max_coefficient, last_updated, version
+			FROM markets
+			WHERE NOT EXISTS (SELECT 1 FROM selections WHERE selections.market_id = markets.id)
+			ORDER BY id`).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`UPDATE coefficient_histories
+			SET selection_id = (SELECT MIN(selections.id) FROM selections WHERE selections.market_id = coefficient_histories.market_id)
+			WHERE selection_id = 0 OR selection_id IS NULL`).Error
+		if err != nil {
+			return err
+		}
+
+		return groupLegacyOutcomes(tx)
+	})
+	if err != nil {
+		log.Printf("Failed to migrate market odds to selections: %v", err)
+		return
+	}
+
+	for _, column := range marketOddsColumns {
+		if err := DB.Migrator().DropColumn(&models.Market{}, column); err != nil {
+			log.Printf("Failed to drop column markets.%s: %v", column, err)
+		}
+	}
+}
+
+// groupLegacyOutcomes merges the markets of each event and type into the
+// market with the lowest ID. Markets without a type are grouped per event.
+func groupLegacyOutcomes(tx *gorm.DB) error {
+	var groups []struct {
+		EventID  uint
+		Type     string
+		MarketID uint
+	}
+	err := tx.Raw(`SELECT event_id, COALESCE(type, '') AS type, MIN(id) AS market_id FROM markets
+		WHERE deleted_at IS NULL
+		GROUP BY event_id, COALESCE(type, '')
+		HAVING COUNT(*) > 1`).Scan(&groups).Error
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, group := range groups {
+		outcomes := tx.Session(&gorm.Session{NewDB: true}).Table("markets").Select("id").
+			Where("event_id = ? AND COALESCE(type, '') = ? AND deleted_at IS NULL", group.EventID, group.Type)
+		for _, table := range []string{"selections", "coefficient_histories"} {
+			if err := tx.Exec("UPDATE "+table+" SET market_id = ? WHERE market_id IN (?)", group.MarketID, outcomes).Error; err != nil {
+				return err
+			}
+		}
+
+		err := tx.Exec(`UPDATE markets SET deleted_at = ?
+			WHERE event_id = ? AND COALESCE(type, '') = ? AND id <> ? AND deleted_at IS NULL`,
+			now, group.EventID, group.Type, group.MarketID).Error
+		if err != nil {
+			return err
+		}
+		if name, ok := legacyMarketNames[group.Type]; ok {
+			if err := tx.Exec("UPDATE markets SET name = ? WHERE id = ?", name, group.MarketID).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
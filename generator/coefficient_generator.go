@@ -4,43 +4,84 @@ import (
 	"context"
 	"github.com/VaheMuradyan/Sport/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"log"
 	"math/rand"
+	"slices"
+	"sync"
 	"time"
 )
 
+// ingestWindowHeader is services.IngestWindowHeader.
+const ingestWindowHeader = "ingest-window"
+
 type CoefficientGenerator struct {
 	client   proto.CoefficientServiceClient
 	conn     *grpc.ClientConn
-	markets  []MarketConfig
 	running  bool
 	stopChan chan bool
+
+	// mu guards the markets, which acks update from the receiving goroutine.
+	// It is never held while sending, so acks are handled while Send blocks
+	// on flow control.
+	mu         sync.Mutex
+	markets    []MarketConfig
+	nextFeedID uint64
+
+	// sendMu guards the stream and serializes sends on it.
+	sendMu sync.Mutex
+	stream proto.CoefficientService_IngestCoefficientsClient
 }
 
 type MarketConfig struct {
+	ID         uint32
+	EventID    uint32
+	Name       string
+	Selections []SelectionConfig
+}
+
+type SelectionConfig struct {
 	ID             uint32
-	EventID        uint32
 	Name           string
 	MinCoefficient float64
 	MaxCoefficient float64
 	Current        float64
 	Volatility     float64
+	// Team is the team the selection backs. Its price shortens when the team
+	// scores and drifts when the opponent does.
+	Team string
+	// GoalShift multiplies the price of a selection without a team on every
+	// goal of its event.
+	GoalShift float64
+	// Version is the selection version Current was read at, nil until the first
+	// update. Updates are only applied if nobody changed the selection since.
+	Version *uint64
 }
 
-func NewCoefficientGenerator(grpcAddr string) (*CoefficientGenerator, error) {
-	conn, err := grpc.Dial(grpcAddr, grpc.WithInsecure())
+func NewCoefficientGenerator(grpcAddr, apiURL, username, password string) (*CoefficientGenerator, error) {
+	conn, err := grpc.Dial(grpcAddr,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(newLoginCredentials(apiURL, username, password)),
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	client := proto.NewCoefficientServiceClient(conn)
 
+	// The outcomes of each event were separate markets before selections; the
+	// selections migration groups them into markets 1 and 4.
 	markets := []MarketConfig{
-		{ID: 1, EventID: 1, Name: "Atletico Ottawa Win", MinCoefficient: 1.01, MaxCoefficient: 5.0, Current: 1.24, Volatility: 0.15},
-		{ID: 2, EventID: 1, Name: "Draw", MinCoefficient: 2.0, MaxCoefficient: 8.0, Current: 4.65, Volatility: 0.20},
-		{ID: 3, EventID: 1, Name: "York 9 FC Win", MinCoefficient: 5.0, MaxCoefficient: 20.0, Current: 11.1, Volatility: 0.25},
-		{ID: 4, EventID: 2, Name: "Over 2.5 Goals", MinCoefficient: 1.5, MaxCoefficient: 4.0, Current: 2.1, Volatility: 0.18},
-		{ID: 5, EventID: 2, Name: "Under 2.5 Goals", MinCoefficient: 1.5, MaxCoefficient: 4.0, Current: 1.8, Volatility: 0.18},
+		{ID: 1, EventID: 1, Name: "Match Winner", Selections: []SelectionConfig{
+			{ID: 1, Name: "Atletico Ottawa Win", MinCoefficient: 1.01, MaxCoefficient: 5.0, Current: 1.24, Volatility: 0.15, Team: "Atletico Ottawa"},
+			{ID: 2, Name: "Draw", MinCoefficient: 2.0, MaxCoefficient: 8.0, Current: 4.65, Volatility: 0.20, GoalShift: 1.15},
+			{ID: 3, Name: "York 9 FC Win", MinCoefficient: 5.0, MaxCoefficient: 20.0, Current: 11.1, Volatility: 0.25, Team: "York 9 FC"},
+		}},
+		{ID: 4, EventID: 2, Name: "Total Goals 2.5", Selections: []SelectionConfig{
+			{ID: 4, Name: "Over 2.5 Goals", MinCoefficient: 1.5, MaxCoefficient: 4.0, Current: 2.1, Volatility: 0.18, GoalShift: 0.8},
+			{ID: 5, Name: "Under 2.5 Goals", MinCoefficient: 1.5, MaxCoefficient: 4.0, Current: 1.8, Volatility: 0.18, GoalShift: 1.25},
+		}},
 	}
 
 	return &CoefficientGenerator{
@@ -52,7 +93,11 @@ func NewCoefficientGenerator(grpcAddr string) (*CoefficientGenerator, error) {
 }
 
 func (og *CoefficientGenerator) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	og.running = true
+	go og.followIncidents(ctx)
 	ticker := time.NewTicker(time.Duration(3+rand.Intn(3)) * time.Second)
 	defer ticker.Stop()
 
@@ -69,7 +114,7 @@ func (og *CoefficientGenerator) Start(ctx context.Context) {
 			og.running = false
 			return
 		case <-ticker.C:
-			og.generateAndUpdateCoefficient()
+			og.generateAndUpdateCoefficients(ctx)
 			ticker.Reset(time.Duration(3+rand.Intn(3)) * time.Second)
 		}
 	}
@@ -82,64 +127,251 @@ func (og *CoefficientGenerator) Stop() {
 	og.conn.Close()
 }
 
-func (og *CoefficientGenerator) generateAndUpdateCoefficient() {
-	market := &og.markets[rand.Intn(len(og.markets))]
-
-	changePercent := (rand.Float64() - 0.5) * 2 * market.Volatility
-	newOdds := market.Current * (1 + changePercent)
+// generateAndUpdateCoefficients moves the odds of all selections of a random
+// event together, so clients never see the book half updated. The change is
+// sent on the ingest stream and applied locally once it is acknowledged.
+func (og *CoefficientGenerator) generateAndUpdateCoefficients(ctx context.Context) {
+	og.mu.Lock()
+	eventID := og.markets[rand.Intn(len(og.markets))].EventID
 
-	if newOdds < market.MinCoefficient {
-		newOdds = market.MinCoefficient
-	}
-	if newOdds > market.MaxCoefficient {
-		newOdds = market.MaxCoefficient
+	reasons := []string{
+		"Market movement",
+		"Betting volume increase",
+		"Team news impact",
+		"Live game events",
+		"Automated adjustment",
 	}
+	reason := reasons[rand.Intn(len(reasons))]
 
-	newOdds = float64(int(newOdds*100)) / 100
+	og.nextFeedID++
+	req := &proto.IngestCoefficientsRequest{FeedId: og.nextFeedID}
+	og.eachSelection(eventID, func(market *MarketConfig, selection *SelectionConfig) {
+		changePercent := (rand.Float64() - 0.5) * 2 * selection.Volatility
+		newOdds := selection.Current * (1 + changePercent)
+		newOdds = selection.clamp(newOdds)
+		if newOdds == selection.Current {
+			return
+		}
 
-	if newOdds == market.Current {
+		direction := "📈"
+		if newOdds < selection.Current {
+			direction = "📉"
+		}
+		log.Printf("🎯 Updating Market [%s / %s] %s: %.2f → %.2f %s",
+			market.Name, selection.Name, direction, selection.Current, newOdds, reason)
+
+		req.Changes = append(req.Changes, &proto.MarketCoefficientChange{
+			SelectionId:     selection.ID,
+			NewCoefficient:  newOdds,
+			ExpectedVersion: selection.Version,
+		})
+	})
+	og.mu.Unlock()
+	if len(req.Changes) == 0 {
 		return
 	}
 
-	oldOdds := market.Current
-	market.Current = newOdds
+	og.send(ctx, eventID, req)
+}
+
+// send sends the update on the ingest stream, opening it if needed.
+func (og *CoefficientGenerator) send(ctx context.Context, eventID uint32, req *proto.IngestCoefficientsRequest) {
+	og.sendMu.Lock()
+	defer og.sendMu.Unlock()
 
-	direction := "📈"
-	if newOdds < oldOdds {
-		direction = "📉"
+	if og.stream == nil {
+		if err := og.openStream(ctx); err != nil {
+			log.Printf("❌ Failed to open ingest stream: %v", err)
+			return
+		}
 	}
+	if err := og.stream.Send(req); err != nil {
+		log.Printf("❌ Failed to send coefficients for event %d: %v", eventID, err)
+		og.stream = nil
+	}
+}
 
-	reasons := []string{
-		"Market movement",
-		"Betting volume increase",
-		"Team news impact",
-		"Live game events",
-		"Automated adjustment",
+// followIncidents watches the events of the generator for incidents and
+// reprices their markets on goals. The stream is reopened when it fails.
+func (og *CoefficientGenerator) followIncidents(ctx context.Context) {
+	og.mu.Lock()
+	var eventIDs []uint32
+	for _, market := range og.markets {
+		if !slices.Contains(eventIDs, market.EventID) {
+			eventIDs = append(eventIDs, market.EventID)
+		}
 	}
-	reason := reasons[rand.Intn(len(reasons))]
+	og.mu.Unlock()
 
-	log.Printf("🎯 Updating Market [%s] %s: %.2f → %.2f %s",
-		market.Name, direction, oldOdds, newOdds, reason)
+	for ctx.Err() == nil {
+		stream, err := og.client.StreamCoefficientUpdates(ctx, &proto.StreamCoefficientRequest{EventIds: eventIDs})
+		if err == nil {
+			for {
+				var event *proto.CoefficientUpdateEvent
+				event, err = stream.Recv()
+				if err != nil {
+					break
+				}
+				if event.Type == "incident" && event.Incident.GetType() == "goal" {
+					og.reactToGoal(ctx, event.EventId, event.Incident)
+				}
+			}
+		}
+		if status.Code(err) == codes.Canceled || ctx.Err() != nil {
+			return
+		}
 
-	req := &proto.UpdateCoefficientRequest{
-		MarketId:       market.ID,
-		NewCoefficient: newOdds,
-		UserId:         1,
+		log.Printf("❌ Incident stream closed: %v, reconnecting", err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+		}
 	}
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// reactToGoal shortens the price of the scoring team, lengthens the price of
+// its opponent and moves the other selections of the event by their
+// GoalShift.
+func (og *CoefficientGenerator) reactToGoal(ctx context.Context, eventID uint32, incident *proto.Incident) {
+	og.mu.Lock()
+	log.Printf("⚽ Goal for %s in event %d (%d')", incident.TeamName, eventID, incident.Minute)
 
-	resp, err := og.client.UpdateCoefficient(ctx, req)
+	og.nextFeedID++
+	req := &proto.IngestCoefficientsRequest{FeedId: og.nextFeedID}
+	og.eachSelection(eventID, func(market *MarketConfig, selection *SelectionConfig) {
+		shift := selection.GoalShift
+		switch {
+		case selection.Team == "":
+		case selection.Team == incident.TeamName:
+			shift = 0.75
+		default:
+			shift = 1.3
+		}
+		if shift == 0 {
+			return
+		}
+
+		newOdds := selection.clamp(selection.Current * shift)
+		if newOdds == selection.Current {
+			return
+		}
+		log.Printf("🎯 Repricing Market [%s / %s] after goal: %.2f → %.2f",
+			market.Name, selection.Name, selection.Current, newOdds)
+
+		req.Changes = append(req.Changes, &proto.MarketCoefficientChange{
+			SelectionId:     selection.ID,
+			NewCoefficient:  newOdds,
+			ExpectedVersion: selection.Version,
+		})
+	})
+	og.mu.Unlock()
+	if len(req.Changes) == 0 {
+		return
+	}
+
+	og.send(ctx, eventID, req)
+}
+
+// openStream opens the ingest stream and starts receiving its acks. The
+// caller holds sendMu.
+func (og *CoefficientGenerator) openStream(ctx context.Context) error {
+	stream, err := og.client.IngestCoefficients(ctx)
+	if err != nil {
+		return err
+	}
+	header, err := stream.Header()
 	if err != nil {
-		log.Printf("❌ Failed to update coefficient for market %d: %v", market.ID, err)
+		return err
+	}
+	log.Printf("🔌 Ingest stream opened, server window %v", header.Get(ingestWindowHeader))
+
+	og.stream = stream
+	go og.receiveAcks(stream)
+	return nil
+}
+
+func (og *CoefficientGenerator) receiveAcks(stream proto.CoefficientService_IngestCoefficientsClient) {
+	for {
+		ack, err := stream.Recv()
+		if err != nil {
+			if status.Code(err) != codes.Canceled {
+				log.Printf("❌ Ingest stream closed: %v", err)
+			}
+			og.sendMu.Lock()
+			if og.stream == stream {
+				og.stream = nil
+			}
+			og.sendMu.Unlock()
+			return
+		}
+		og.handleAck(ack)
+	}
+}
+
+func (og *CoefficientGenerator) handleAck(ack *proto.IngestCoefficientsAck) {
+	og.mu.Lock()
+	defer og.mu.Unlock()
+
+	if !ack.Accepted {
+		if current := ack.Current; current != nil {
+			for _, update := range current.Selections {
+				if selection := og.selection(update.SelectionId); selection != nil {
+					selection.Current = update.CurrentCoefficient
+					version := update.Version
+					selection.Version = &version
+				}
+			}
+			log.Printf("🔄 Market %d was changed concurrently, resynced %d selections",
+				current.MarketId, len(current.Selections))
+			return
+		}
+		log.Printf("❌ Feed update %d rejected (%s): %s", ack.FeedId, ack.RejectReason, ack.Message)
 		return
 	}
 
-	if resp.Success {
-		log.Printf("✅ Successfully updated market %d ocefficient: %.2f → %.2f",
-			resp.MarketId, resp.OldCoefficient, resp.NewCoefficient)
-	} else {
-		log.Printf("❌ Failed to update market %d: %s", market.ID, resp.Message)
+	for _, update := range ack.Updates {
+		if selection := og.selection(update.SelectionId); selection != nil {
+			selection.Current = update.NewCoefficient
+			version := update.Version
+			selection.Version = &version
+		}
+	}
+	log.Printf("✅ Feed update %d applied to %d selections (sequence %d)",
+		ack.FeedId, len(ack.Updates), ack.Sequence)
+}
+
+// clamp keeps odds within the bounds of the selection, rounded down to cents.
+func (s *SelectionConfig) clamp(odds float64) float64 {
+	if odds < s.MinCoefficient {
+		odds = s.MinCoefficient
+	}
+	if odds > s.MaxCoefficient {
+		odds = s.MaxCoefficient
+	}
+	return float64(int(odds*100)) / 100
+}
+
+// eachSelection calls fn for every selection of the markets of the event. The
+// caller holds mu.
+func (og *CoefficientGenerator) eachSelection(eventID uint32, fn func(*MarketConfig, *SelectionConfig)) {
+	for i := range og.markets {
+		market := &og.markets[i]
+		if market.EventID != eventID {
+			continue
+		}
+		for j := range market.Selections {
+			fn(market, &market.Selections[j])
+		}
+	}
+}
+
+func (og *CoefficientGenerator) selection(id uint32) *SelectionConfig {
+	for i := range og.markets {
+		for j := range og.markets[i].Selections {
+			if og.markets[i].Selections[j].ID == id {
+				return &og.markets[i].Selections[j]
+			}
+		}
 	}
+	return nil
 }
@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// loginCredentials attaches a bearer token to every gRPC call. The token is
+// obtained from the HTTP login endpoint and renewed shortly before it
+// expires.
+type loginCredentials struct {
+	loginURL string
+	username string
+	password string
+	client   *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+type loginResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func newLoginCredentials(apiURL, username, password string) *loginCredentials {
+	return &loginCredentials{
+		loginURL: apiURL + "/api/v1/auth/login",
+		username: username,
+		password: password,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (l *loginCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := l.currentToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (l *loginCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func (l *loginCredentials) currentToken(ctx context.Context) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.token != "" && time.Until(l.expiresAt) > time.Minute {
+		return l.token, nil
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"username": l.username,
+		"password": l.password,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", l.loginURL, bytes.NewBuffer(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create login request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to log in: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("login rejected with status code: %d", resp.StatusCode)
+	}
+
+	var login loginResponse
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+		return "", fmt.Errorf("failed to decode login response: %v", err)
+	}
+
+	l.token = login.Token
+	l.expiresAt = login.ExpiresAt
+	return l.token, nil
+}
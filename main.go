@@ -1,44 +1,144 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"github.com/VaheMuradyan/Sport/api"
+	"github.com/VaheMuradyan/Sport/auth"
+	"github.com/VaheMuradyan/Sport/config"
 	"github.com/VaheMuradyan/Sport/db"
+	"github.com/VaheMuradyan/Sport/models"
 	"github.com/VaheMuradyan/Sport/proto"
 	"github.com/VaheMuradyan/Sport/services"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 	"log"
 	"net"
+	"os"
+	"slices"
+	"time"
 )
 
 func main() {
-	database := db.ConnectDB()
-	coefficientService := services.NewCoefficientService(database)
-	centrifugoService := services.NewCentrifugoService("http://localhost:8000", "0957bfe1-5aa9-40c0-991f-d15150f91594")
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if err := cfg.ValidateServer(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	tokens := auth.NewTokenManager(cfg.Auth.JWTSecret, "sport", cfg.Auth.TokenTTL)
+	centrifugoTokens := auth.NewCentrifugoTokens(cfg.Centrifugo.TokenSecret, cfg.Centrifugo.TokenTTL, cfg.Centrifugo.History.Enabled)
+
+	database := db.ConnectDB(cfg.Database.DSN)
+	if err := services.SeedMarketTemplates(database); err != nil {
+		log.Printf("Failed to seed market templates: %v", err)
+	}
+	permissionService := services.NewPermissionService(database)
+	coefficientService := services.NewCoefficientService(database, permissionService, cfg.Publisher.PublishGlobal)
+
+	centrifugoAPI, err := services.NewCentrifugoAPI(cfg.Centrifugo)
+	if err != nil {
+		log.Fatalf("Failed to create Centrifugo API client: %v", err)
+	}
+	defer centrifugoAPI.Close()
+	if cfg.Centrifugo.History.Enabled && slices.Contains(cfg.Publisher.Backends, config.BackendCentrifugo) {
+		checkCentrifugoHistory(centrifugoAPI)
+	}
+
+	publisher, err := services.NewPublisher(cfg.Publisher, centrifugoAPI, cfg.Centrifugo.Batch)
+	if err != nil {
+		log.Fatalf("Failed to create publisher: %v", err)
+	}
+	defer publisher.Close()
+
+	outboxDispatcher := services.NewOutboxDispatcher(database, publisher, cfg.Outbox)
+	go outboxDispatcher.Run(context.Background())
 
-	go startGRPCServer(coefficientService, centrifugoService)
+	coefficientBroker := services.NewCoefficientBroker(256)
+	coefficientUpdater := services.NewCoefficientUpdater(coefficientService, outboxDispatcher, coefficientBroker)
+
+	audienceService := services.NewAudienceService(database, centrifugoAPI, cfg.Audience)
+	if cfg.Audience.Enabled {
+		go audienceService.Run(context.Background())
+	}
 
-	startHTTPServer()
+	userService := services.NewUserService(database)
+	provisionAccounts(cfg, userService)
 
+	go startGRPCServer(cfg.Server.GRPCAddr, services.NewGRPCCoefficientServer(coefficientService, coefficientUpdater, coefficientBroker, cfg.Server.IngestWindow), tokens)
+
+	startHTTPServer(cfg.Server.HTTPAddr, api.NewServer(database, coefficientService, coefficientUpdater, audienceService, userService, permissionService, tokens, centrifugoTokens))
+}
+
+// checkCentrifugoHistory warns when Centrifugo does not keep history for the
+// odds channels, in which case clients cannot recover missed updates. The top
+// level options cover the global channel and the "odds" namespace options
+// every other odds channel.
+func checkCentrifugoHistory(centrifugoAPI services.CentrifugoAPI) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	probes := []struct{ channel, settings string }{
+		{services.GlobalOddsChannel, "the top level history_size and history_ttl"},
+		{services.HistoryProbeChannel, "history_size and history_ttl of the odds namespace"},
+	}
+	for _, probe := range probes {
+		if _, err := centrifugoAPI.History(ctx, probe.channel, services.HistoryOptions{}); err != nil {
+			log.Printf("⚠️ Centrifugo history is not available for %s, set %s; clients will fall back to /api/v1/history: %v", probe.channel, probe.settings, err)
+		}
+	}
+}
+
+// provisionAccounts creates the bootstrap admin and the odds generator's
+// service account, and with auth.reset_accounts resets existing ones. The
+// generator account is a feed bot that may only move odds in
+// generator.sport_id.
+func provisionAccounts(cfg *config.Config, userService *services.UserService) {
+	if cfg.Auth.AdminUsername != "" {
+		if _, err := userService.EnsureAccount(cfg.Auth.AdminUsername, cfg.Auth.AdminPassword, models.RoleAdmin, cfg.Auth.ResetAccounts); err != nil {
+			log.Fatalf("Failed to provision admin account: %v", err)
+		}
+	}
+
+	if cfg.Generator.Username == "" {
+		return
+	}
+
+	account, err := userService.EnsureAccount(cfg.Generator.Username, cfg.Generator.Password, models.RoleFeedBot, cfg.Auth.ResetAccounts)
+	if err != nil {
+		log.Fatalf("Failed to provision generator account: %v", err)
+	}
+	if err := userService.EnsureSportPermission(account.ID, cfg.Generator.SportID); err != nil {
+		log.Fatalf("Failed to grant generator permission: %v", err)
+	}
 }
 
-func startGRPCServer(coefficientService *services.CoefficientService, centrifugoService *services.CentrifugoService) {
-	lis, err := net.Listen("tcp", ":50051")
+func startGRPCServer(addr string, coefficientServer *services.GRPCCoefficientServer, tokens *auth.TokenManager) {
+	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	proto.RegisterCoefficientServiceServer(grpcServer, services.NewGRPCCoefficientServer(coefficientService, centrifugoService))
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(tokens)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(tokens)),
+	)
+	proto.RegisterCoefficientServiceServer(grpcServer, coefficientServer)
 
-	log.Println("gRPC server listening on :50051")
+	log.Printf("gRPC server listening on %s", addr)
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve gRPC: %v", err)
 	}
 }
 
-func startHTTPServer() {
+func startHTTPServer(addr string, apiServer *api.Server) {
 	r := gin.Default()
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	apiServer.RegisterRoutes(r)
 
-	log.Println("HTTP server listening on :8080")
-	r.Run(":8080")
+	log.Printf("HTTP server listening on %s", addr)
+	r.Run(addr)
 }
@@ -5,67 +5,252 @@ import (
 	"time"
 )
 
+const (
+	RoleAdmin    = "admin"
+	RoleTrader   = "trader"
+	RoleReadOnly = "read_only"
+	RoleFeedBot  = "feed_bot"
+)
+
 type User struct {
 	gorm.Model
-	Username string `gorm:"unique" json:"username"`
-	Password string `json:"password"`
+	Username     string              `gorm:"unique" json:"username"`
+	PasswordHash string              `gorm:"column:password" json:"-"` // bcrypt hash
+	Role         string              `gorm:"size:20;default:'read_only'" json:"role"`
+	Permissions  []TradingPermission `gorm:"foreignKey:UserID" json:"permissions,omitempty"`
+}
+
+// TradingPermission lets a trader or feed bot move odds of every market in
+// one sport or in one competition. Exactly one of SportID and CompetitionID
+// is set.
+type TradingPermission struct {
+	gorm.Model
+	UserID        uint  `json:"user_id"`
+	SportID       *uint `json:"sport_id,omitempty"`
+	CompetitionID *uint `json:"competition_id,omitempty"`
+}
+
+// AuditLog records actions that were denied to a user.
+type AuditLog struct {
+	gorm.Model
+	UserID     uint   `gorm:"index" json:"user_id"`
+	Action     string `json:"action"`
+	Resource   string `json:"resource"`
+	ResourceID uint   `json:"resource_id"`
+	Reason     string `json:"reason"`
+}
+
+const (
+	OutboxPending   = "pending"
+	OutboxDelivered = "delivered"
+	OutboxDead      = "dead"
+)
+
+// OutboxMessage is a publication written in the same transaction
+// as the change it announces. The outbox dispatcher delivers it and marks it
+// dead once it runs out of attempts.
+type OutboxMessage struct {
+	gorm.Model
+	Channels      string     `gorm:"type:text" json:"channels"` // comma separated
+	Payload       string     `gorm:"type:text" json:"payload"`  // JSON
+	Status        string     `gorm:"size:20;index;default:'pending'" json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"index" json:"next_attempt_at"`
+	LastError     string     `gorm:"type:text" json:"last_error,omitempty"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+	ClaimedBy     string     `gorm:"size:100" json:"claimed_by,omitempty"` // the dispatcher delivering the message
+	LockedUntil   *time.Time `gorm:"index" json:"locked_until,omitempty"`  // the end of its lease
+}
+
+// AudienceSample is the number of clients and users subscribed to the channel
+// of an event, or of one of its markets when MarketID is set, at SampledAt.
+type AudienceSample struct {
+	gorm.Model
+	EventID    uint      `gorm:"index:idx_audience_event_sampled" json:"event_id"`
+	MarketID   *uint     `gorm:"index" json:"market_id,omitempty"`
+	Channel    string    `gorm:"size:100" json:"channel"`
+	NumClients uint32    `json:"num_clients"`
+	NumUsers   uint32    `json:"num_users"`
+	SampledAt  time.Time `gorm:"index:idx_audience_event_sampled" json:"sampled_at"`
 }
 
 type Country struct {
 	gorm.Model
-	Name         string
+	Name         string        `json:"name"`
 	Competitions []Competition `gorm:"foreignKey:CountryID" json:"competitions,omitempty"`
 	Code         string        `gorm:"unique;size:3" json:"code"`
 }
 
 type Competition struct {
 	gorm.Model
-	Name      string  `json:"name"`
-	CountryID uint    `json:"country_id"`
-	Country   Country `gorm:"foreignKey:CountryID" json:"country,omitempty"`
-	SportID   uint    `json:"sport_id"`
-	Sport     Sport   `gorm:"foreignKey:SportID" json:"sport,omitempty"`
-	Teams     []Team  `gorm:"many2many:competition_teams;" json:"teams,omitempty"`
-	Events    []Event `gorm:"foreignKey:CompetitionID" json:"events,omitempty"`
-	Active    bool    `gorm:"default:true" json:"active"`
+	Name      string   `json:"name"`
+	CountryID uint     `json:"country_id"`
+	Country   *Country `gorm:"foreignKey:CountryID" json:"country,omitempty"`
+	SportID   uint     `json:"sport_id"`
+	Sport     *Sport   `gorm:"foreignKey:SportID" json:"sport,omitempty"`
+	Teams     []Team   `gorm:"many2many:competition_teams;" json:"teams,omitempty"`
+	Events    []Event  `gorm:"foreignKey:CompetitionID" json:"events,omitempty"`
+	Active    bool     `gorm:"default:true" json:"active"`
 }
 
+// Market statuses. Odds can only be updated while a market is open; closed
+// markets are settled or voided once the result is known.
+const (
+	MarketOpen      = "open"
+	MarketSuspended = "suspended"
+	MarketClosed    = "closed"
+	MarketSettled   = "settled"
+	MarketVoided    = "voided"
+)
+
+// Market types with typed parameters. Markets of other types take no
+// parameters.
+const (
+	MarketMatchWinner = "match_winner"
+	MarketOverUnder   = "over_under"
+	MarketHandicap    = "handicap"
+	MarketTeamTotal   = "team_total"
+	MarketPlayerGoals = "player_goals"
+	MarketBothToScore = "both_teams_to_score"
+)
+
 type Market struct {
 	gorm.Model
+	Name               string               `json:"name"`
+	Type               string               `json:"type"` // match_winner, over_under, handicap, etc.
+	EventID            uint                 `json:"event_id"`
+	Event              *Event               `gorm:"foreignKey:EventID" json:"event,omitempty"`
+	Line               *float64             `gorm:"type:decimal(6,2)" json:"line,omitempty"` // the 2.5 of over 2.5 goals, the -1.5 of a handicap
+	Period             string               `gorm:"size:20" json:"period,omitempty"`         // the whole match when empty
+	Player             string               `json:"player,omitempty"`
+	TeamID             *uint                `json:"team_id,omitempty"`
+	Team               *Team                `gorm:"foreignKey:TeamID" json:"team,omitempty"`
+	TemplateID         *uint                `gorm:"index" json:"template_id,omitempty"` // the template the market was generated from
+	Selections         []Selection          `gorm:"foreignKey:MarketID" json:"selections,omitempty"`
+	Status             string               `gorm:"size:16;default:'open';index" json:"status"`
+	Active             bool                 `gorm:"default:true" json:"active"` // status is open
+	CoefficientHistory []CoefficientHistory `gorm:"foreignKey:MarketID" json:"coefficient_history,omitempty"`
+	Version            uint64               `gorm:"default:0" json:"version"` // incremented by every change, odds are versioned per selection
+}
+
+// Selection is a priced outcome of a market, such as the home win of a match
+// winner market.
+type Selection struct {
+	gorm.Model
+	MarketID            uint                 `gorm:"index" json:"market_id"`
+	Market              *Market              `gorm:"foreignKey:MarketID" json:"market,omitempty"`
 	Name                string               `json:"name"`
-	Type                string               `json:"type"` // match_winner, over_under, handicap, etc.
-	EventID             uint                 `json:"event_id"`
-	Event               Event                `gorm:"foreignKey:EventID" json:"event,omitempty"`
 	CurrentCoefficient  float64              `gorm:"type:decimal(9,4);" json:"current_coefficient"`
 	PreviousCoefficient float64              `gorm:"type:decimal(9,4);" json:"previous_coefficient"`
 	MinCoefficient      float64              `gorm:"type:decimal(9,4);default:1.01" json:"min_coefficient"`
 	MaxCoefficient      float64              `gorm:"type:decimal(9,4);default:100.00" json:"max_coefficient"`
-	Active              bool                 `gorm:"default:true" json:"active"`
-	CoefficientHistory  []CoefficientHistory `gorm:"foreignKey:MarketID" json:"coefficient_history,omitempty"`
+	CoefficientHistory  []CoefficientHistory `gorm:"foreignKey:SelectionID" json:"coefficient_history,omitempty"`
 	LastUpdated         time.Time            `json:"last_updated"`
+	Version             uint64               `gorm:"default:0" json:"version"` // incremented by every change
 }
 
 type CoefficientHistory struct {
 	gorm.Model
-	MarketID    uint      `json:"market_id"`
-	Market      Market    `gorm:"foreignKey:MarketID" json:"market,omitempty"`
-	OldValue    float64   `gorm:"type:decimal(9,4)" json:"old_value"`
-	NewValue    float64   `gorm:"type:decimal(9,4)" json:"new_value"`
+	MarketID    uint       `json:"market_id"` // market of the selection
+	Market      *Market    `gorm:"foreignKey:MarketID" json:"market,omitempty"`
+	SelectionID uint       `gorm:"index" json:"selection_id"`
+	Selection   *Selection `gorm:"foreignKey:SelectionID" json:"selection,omitempty"`
+	Line        *float64   `gorm:"type:decimal(6,2)" json:"line,omitempty"` // line of the market the odds were quoted at
+	OldValue    float64    `gorm:"type:decimal(9,4)" json:"old_value"`
+	NewValue    float64    `gorm:"type:decimal(9,4)" json:"new_value"`
+	ChangedByID uint       `json:"changed_by_id"`
+	ChangedBy   *User      `gorm:"foreignKey:ChangedByID" json:"changed_by,omitempty"`
+	Timestamp   time.Time  `json:"timestamp"`
+	Sequence    uint64     `json:"sequence"` // event sequence of the update
+}
+
+// LineHistory is a move of the line of a market.
+type LineHistory struct {
+	gorm.Model
+	MarketID    uint      `gorm:"index" json:"market_id"`
+	OldLine     *float64  `gorm:"type:decimal(6,2)" json:"old_line"` // nil for the first line of a market
+	NewLine     float64   `gorm:"type:decimal(6,2)" json:"new_line"`
 	ChangedByID uint      `json:"changed_by_id"`
-	ChangedBy   User      `gorm:"foreignKey:ChangedByID" json:"changed_by,omitempty"`
+	ChangedBy   *User     `gorm:"foreignKey:ChangedByID" json:"changed_by,omitempty"`
 	Timestamp   time.Time `json:"timestamp"`
+	Sequence    uint64    `json:"sequence"` // event sequence of the move
 }
 
+// Event statuses. Finished and cancelled events are final.
+const (
+	EventScheduled = "scheduled"
+	EventPreMatch  = "pre_match"
+	EventLive      = "live"
+	EventHalfTime  = "half_time"
+	EventFinished  = "finished"
+	EventPostponed = "postponed"
+	EventCancelled = "cancelled"
+)
+
 type Event struct {
 	gorm.Model
-	Name          string      `json:"name"`
-	CompetitionID uint        `json:"competition_id"`
-	Competition   Competition `gorm:"foreignKey:CompetitionID" json:"competition,omitempty"`
-	Markets       []Market    `gorm:"foreignKey:EventID" json:"markets,omitempty"`
-	Teams         []Team      `gorm:"many2many:event_teams;" json:"teams,omitempty"`
-	StartTime     time.Time   `json:"start_time"`
-	Status        string      `gorm:"default:'scheduled'" json:"status"`
-	IsLive        bool        `gorm:"default:false" json:"is_live"`
+	Name          string       `json:"name"`
+	CompetitionID uint         `json:"competition_id"`
+	Competition   *Competition `gorm:"foreignKey:CompetitionID" json:"competition,omitempty"`
+	Markets       []Market     `gorm:"foreignKey:EventID" json:"markets,omitempty"`
+	Teams         []Team       `gorm:"many2many:event_teams;" json:"teams,omitempty"`
+	StartTime     time.Time    `json:"start_time"`
+	Status        string       `gorm:"default:'scheduled'" json:"status"`
+	IsLive        bool         `gorm:"default:false" json:"is_live"` // status is live or half_time
+	Sequence      uint64       `gorm:"default:0" json:"sequence"`    // incremented with every update published for the event
+}
+
+// Incident types. Period incidents start and end the periods of an event, the
+// others belong to a team.
+const (
+	IncidentGoal        = "goal"
+	IncidentCard        = "card"
+	IncidentPenalty     = "penalty"
+	IncidentCorner      = "corner"
+	IncidentPeriodStart = "period_start"
+	IncidentPeriodEnd   = "period_end"
+)
+
+// Periods of an event.
+const (
+	PeriodFirstHalf  = "first_half"
+	PeriodSecondHalf = "second_half"
+	PeriodExtraTime  = "extra_time"
+	PeriodPenalties  = "penalties"
+)
+
+// Incident is something that happened in a live event.
+type Incident struct {
+	gorm.Model
+	EventID      uint      `gorm:"index" json:"event_id"`
+	Type         string    `gorm:"size:20" json:"type"`
+	TeamID       *uint     `json:"team_id,omitempty"`
+	Team         *Team     `gorm:"foreignKey:TeamID" json:"team,omitempty"`
+	Period       string    `gorm:"size:20" json:"period"`
+	Minute       int       `json:"minute"`
+	Player       string    `json:"player,omitempty"`
+	Detail       string    `json:"detail,omitempty"` // yellow or red for cards
+	RecordedByID uint      `json:"recorded_by_id"`
+	Timestamp    time.Time `json:"timestamp"`
+	Sequence     uint64    `json:"sequence"` // event sequence of the incident
+}
+
+// EventPeriod is a period of an event, open until EndedAt is set.
+type EventPeriod struct {
+	gorm.Model
+	EventID   uint       `gorm:"uniqueIndex:idx_event_period" json:"event_id"`
+	Period    string     `gorm:"size:20;uniqueIndex:idx_event_period" json:"period"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+// Score is the number of goals a team scored in a period of an event.
+type Score struct {
+	gorm.Model
+	EventID uint   `gorm:"uniqueIndex:idx_event_team_period" json:"event_id"`
+	TeamID  uint   `gorm:"uniqueIndex:idx_event_team_period" json:"team_id"`
+	Period  string `gorm:"size:20;uniqueIndex:idx_event_team_period" json:"period"`
+	Goals   int    `json:"goals"`
 }
 
 type Team struct {
@@ -73,7 +258,7 @@ type Team struct {
 	Name         string        `json:"name"`
 	Rating       int           `json:"rating"`
 	CountryID    uint          `json:"country_id"`
-	Country      Country       `gorm:"foreignKey:CountryID" json:"country,omitempty"`
+	Country      *Country      `gorm:"foreignKey:CountryID" json:"country,omitempty"`
 	Competitions []Competition `gorm:"many2many:competition_teams;" json:"competitions,omitempty"`
 	Events       []Event       `gorm:"many2many:event_teams;" json:"events,omitempty"`
 	Sports       []Sport       `gorm:"many2many:sport_teams;" json:"sports,omitempty"`
@@ -81,27 +266,171 @@ type Team struct {
 
 type Sport struct {
 	gorm.Model
-	Name         string        `json:"name"`
-	Competitions []Competition `gorm:"foreignKey:SportID" json:"competitions,omitempty"`
-	Teams        []Team        `gorm:"many2many:sport_teams;" json:"teams,omitempty"`
-	Code         string        `gorm:"unique" json:"code"`
+	Name            string           `json:"name"`
+	Competitions    []Competition    `gorm:"foreignKey:SportID" json:"competitions,omitempty"`
+	Teams           []Team           `gorm:"many2many:sport_teams;" json:"teams,omitempty"`
+	MarketTemplates []MarketTemplate `gorm:"foreignKey:SportID" json:"market_templates,omitempty"`
+	Code            string           `gorm:"unique" json:"code"`
+}
+
+// MarketTemplate is a market generated with its selections for every new
+// event of a sport. Per team templates, such as a handicap, are generated
+// once for each team of the event; {team} and {opponent} in their names are
+// replaced with the team names.
+type MarketTemplate struct {
+	gorm.Model
+	SportID    uint                `gorm:"index" json:"sport_id"`
+	Sport      *Sport              `gorm:"foreignKey:SportID" json:"sport,omitempty"`
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Line       *float64            `gorm:"type:decimal(6,2)" json:"line,omitempty"`
+	Period     string              `gorm:"size:20" json:"period,omitempty"`
+	PerTeam    bool                `json:"per_team"`
+	Selections []SelectionTemplate `gorm:"foreignKey:MarketTemplateID" json:"selections,omitempty"`
+	Active     bool                `gorm:"default:true" json:"active"`
+}
+
+// SelectionTemplate is a selection of a market template with its initial
+// odds.
+type SelectionTemplate struct {
+	gorm.Model
+	MarketTemplateID uint    `gorm:"index" json:"market_template_id"`
+	Name             string  `json:"name"`
+	Coefficient      float64 `gorm:"type:decimal(9,4)" json:"coefficient"`
+	MinCoefficient   float64 `gorm:"type:decimal(9,4);default:1.01" json:"min_coefficient"`
+	MaxCoefficient   float64 `gorm:"type:decimal(9,4);default:100.00" json:"max_coefficient"`
 }
 
 type UserRequest struct {
-	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Username string `json:"username" binding:"required,min=3,max=64"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+type RoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin trader read_only feed_bot"`
+}
+
+type TradingPermissionRequest struct {
+	SportID       *uint `json:"sport_id"`
+	CompetitionID *uint `json:"competition_id"`
+}
+
+type SubscriptionTokenRequest struct {
+	Channel string `json:"channel" binding:"required"`
+}
+
+type TokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type LoginResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      User      `json:"user"`
 }
 
 type CoefficientUpdateRequest struct {
-	MarketID       uint    `json:"market_id" binding:"required"`
-	NewCoefficient float64 `json:"new_Coefficient" binding:"required"`
+	MarketID        uint    `json:"market_id"`
+	SelectionID     uint    `json:"selection_id"`
+	NewCoefficient  float64 `json:"new_coefficient" binding:"required"`
+	ExpectedVersion *uint64 `json:"expected_version"` // optional compare-and-swap
 }
 
 type CoefficientUpdateResponse struct {
 	Success        bool      `json:"success"`
 	Message        string    `json:"message"`
 	MarketID       uint      `json:"market_id"`
+	SelectionID    uint      `json:"selection_id"`
+	Selection      string    `json:"selection"`
+	Line           *float64  `json:"line,omitempty"`
+	EventID        uint      `json:"event_id"`
+	CompetitionID  uint      `json:"competition_id"`
 	OldCoefficient float64   `json:"old_coefficient"`
 	NewCoefficient float64   `json:"new_coefficient"`
 	UpdatedAt      time.Time `json:"updated_at"`
+	Sequence       uint64    `json:"sequence"`
+	Version        uint64    `json:"version"`
+}
+
+type MarketStatusRequest struct {
+	Status          string  `json:"status" binding:"required,oneof=open suspended closed settled voided"`
+	Reason          string  `json:"reason"`
+	ExpectedVersion *uint64 `json:"expected_version"` // optional compare-and-swap
+}
+
+type MarketStatusResponse struct {
+	Success        bool      `json:"success"`
+	Message        string    `json:"message"`
+	MarketID       uint      `json:"market_id"`
+	EventID        uint      `json:"event_id"`
+	CompetitionID  uint      `json:"competition_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	Sequence       uint64    `json:"sequence"`
+	Version        uint64    `json:"version"`
+}
+
+type MarketLineRequest struct {
+	Line            *float64              `json:"line" binding:"required"`
+	Coefficients    []SelectionOddsChange `json:"coefficients" binding:"dive"` // odds quoted at the new line
+	ExpectedVersion *uint64               `json:"expected_version"`            // optional compare-and-swap
+}
+
+type SelectionOddsChange struct {
+	SelectionID     uint    `json:"selection_id" binding:"required"`
+	NewCoefficient  float64 `json:"new_coefficient" binding:"required"`
+	ExpectedVersion *uint64 `json:"expected_version"`
+}
+
+type MarketLineResponse struct {
+	Success       bool                        `json:"success"`
+	Message       string                      `json:"message"`
+	MarketID      uint                        `json:"market_id"`
+	EventID       uint                        `json:"event_id"`
+	CompetitionID uint                        `json:"competition_id"`
+	PreviousLine  *float64                    `json:"previous_line"`
+	Line          float64                     `json:"line"`
+	Updates       []CoefficientUpdateResponse `json:"updates"`
+	UpdatedAt     time.Time                   `json:"updated_at"`
+	Sequence      uint64                      `json:"sequence"`
+	Version       uint64                      `json:"version"`
+}
+
+type EventStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=scheduled pre_match live half_time finished postponed cancelled"`
+	Reason string `json:"reason"`
+}
+
+type EventStatusResponse struct {
+	Success        bool                   `json:"success"`
+	Message        string                 `json:"message"`
+	EventID        uint                   `json:"event_id"`
+	CompetitionID  uint                   `json:"competition_id"`
+	PreviousStatus string                 `json:"previous_status"`
+	Status         string                 `json:"status"`
+	IsLive         bool                   `json:"is_live"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	Sequence       uint64                 `json:"sequence"`
+	Markets        []MarketStatusResponse `json:"markets"` // markets whose status changed with the event
+}
+
+type IncidentRequest struct {
+	Type   string `json:"type" binding:"required,oneof=goal card penalty corner period_start period_end"`
+	TeamID *uint  `json:"team_id"`
+	Period string `json:"period" binding:"required,oneof=first_half second_half extra_time penalties"`
+	Minute int    `json:"minute" binding:"min=0"`
+	Player string `json:"player"`
+	Detail string `json:"detail"`
+}
+
+type BatchCoefficientUpdateResponse struct {
+	Success       bool                        `json:"success"`
+	Message       string                      `json:"message"`
+	EventID       uint                        `json:"event_id"`
+	CompetitionID uint                        `json:"competition_id"`
+	UpdatedAt     time.Time                   `json:"updated_at"`
+	Sequence      uint64                      `json:"sequence"`
+	Updates       []CoefficientUpdateResponse `json:"updates"`
 }
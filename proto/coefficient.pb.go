@@ -21,13 +21,92 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type IngestCoefficientsAck_RejectReason int32
+
+const (
+	IngestCoefficientsAck_REJECT_REASON_UNSPECIFIED IngestCoefficientsAck_RejectReason = 0
+	IngestCoefficientsAck_INVALID                   IngestCoefficientsAck_RejectReason = 1
+	IngestCoefficientsAck_MARKET_NOT_FOUND          IngestCoefficientsAck_RejectReason = 2
+	IngestCoefficientsAck_OUT_OF_BOUNDS             IngestCoefficientsAck_RejectReason = 3
+	IngestCoefficientsAck_PERMISSION_DENIED         IngestCoefficientsAck_RejectReason = 4
+	IngestCoefficientsAck_VERSION_CONFLICT          IngestCoefficientsAck_RejectReason = 5
+	IngestCoefficientsAck_INTERNAL                  IngestCoefficientsAck_RejectReason = 6
+	IngestCoefficientsAck_MARKET_NOT_OPEN           IngestCoefficientsAck_RejectReason = 7
+	IngestCoefficientsAck_SELECTION_NOT_FOUND       IngestCoefficientsAck_RejectReason = 8
+)
+
+// Enum value maps for IngestCoefficientsAck_RejectReason.
+var (
+	IngestCoefficientsAck_RejectReason_name = map[int32]string{
+		0: "REJECT_REASON_UNSPECIFIED",
+		1: "INVALID",
+		2: "MARKET_NOT_FOUND",
+		3: "OUT_OF_BOUNDS",
+		4: "PERMISSION_DENIED",
+		5: "VERSION_CONFLICT",
+		6: "INTERNAL",
+		7: "MARKET_NOT_OPEN",
+		8: "SELECTION_NOT_FOUND",
+	}
+	IngestCoefficientsAck_RejectReason_value = map[string]int32{
+		"REJECT_REASON_UNSPECIFIED": 0,
+		"INVALID":                   1,
+		"MARKET_NOT_FOUND":          2,
+		"OUT_OF_BOUNDS":             3,
+		"PERMISSION_DENIED":         4,
+		"VERSION_CONFLICT":          5,
+		"INTERNAL":                  6,
+		"MARKET_NOT_OPEN":           7,
+		"SELECTION_NOT_FOUND":       8,
+	}
+)
+
+func (x IngestCoefficientsAck_RejectReason) Enum() *IngestCoefficientsAck_RejectReason {
+	p := new(IngestCoefficientsAck_RejectReason)
+	*p = x
+	return p
+}
+
+func (x IngestCoefficientsAck_RejectReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IngestCoefficientsAck_RejectReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_coefficient_proto_enumTypes[0].Descriptor()
+}
+
+func (IngestCoefficientsAck_RejectReason) Type() protoreflect.EnumType {
+	return &file_proto_coefficient_proto_enumTypes[0]
+}
+
+func (x IngestCoefficientsAck_RejectReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IngestCoefficientsAck_RejectReason.Descriptor instead.
+func (IngestCoefficientsAck_RejectReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{6, 0}
+}
+
 type UpdateCoefficientRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	MarketId       uint32                 `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
-	NewCoefficient float64                `protobuf:"fixed64,2,opt,name=new_coefficient,json=newCoefficient,proto3" json:"new_coefficient,omitempty"`
-	UserId         uint32                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: use selection_id. Without it, the only selection of the
+	// market is updated.
+	//
+	// Deprecated: Marked as deprecated in proto/coefficient.proto.
+	MarketId       uint32  `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	NewCoefficient float64 `protobuf:"fixed64,2,opt,name=new_coefficient,json=newCoefficient,proto3" json:"new_coefficient,omitempty"`
+	// Ignored: the change is attributed to the caller of the bearer token.
+	//
+	// Deprecated: Marked as deprecated in proto/coefficient.proto.
+	UserId uint32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// When set, the update is only applied if the selection is still at this
+	// version. Otherwise the call fails with ABORTED and a
+	// GetMarketCoefficientResponse with the current market in the details.
+	ExpectedVersion *uint64 `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	SelectionId     uint32  `protobuf:"varint,5,opt,name=selection_id,json=selectionId,proto3" json:"selection_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateCoefficientRequest) Reset() {
@@ -60,6 +139,7 @@ func (*UpdateCoefficientRequest) Descriptor() ([]byte, []int) {
 	return file_proto_coefficient_proto_rawDescGZIP(), []int{0}
 }
 
+// Deprecated: Marked as deprecated in proto/coefficient.proto.
 func (x *UpdateCoefficientRequest) GetMarketId() uint32 {
 	if x != nil {
 		return x.MarketId
@@ -74,6 +154,7 @@ func (x *UpdateCoefficientRequest) GetNewCoefficient() float64 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/coefficient.proto.
 func (x *UpdateCoefficientRequest) GetUserId() uint32 {
 	if x != nil {
 		return x.UserId
@@ -81,6 +162,20 @@ func (x *UpdateCoefficientRequest) GetUserId() uint32 {
 	return 0
 }
 
+func (x *UpdateCoefficientRequest) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateCoefficientRequest) GetSelectionId() uint32 {
+	if x != nil {
+		return x.SelectionId
+	}
+	return 0
+}
+
 type UpdateCoefficientResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -89,8 +184,15 @@ type UpdateCoefficientResponse struct {
 	OldCoefficient float64                `protobuf:"fixed64,4,opt,name=old_coefficient,json=oldCoefficient,proto3" json:"old_coefficient,omitempty"`
 	NewCoefficient float64                `protobuf:"fixed64,5,opt,name=new_coefficient,json=newCoefficient,proto3" json:"new_coefficient,omitempty"`
 	UpdatedAt      int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	Sequence       uint64                 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The version of the selection.
+	Version     uint64 `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	SelectionId uint32 `protobuf:"varint,9,opt,name=selection_id,json=selectionId,proto3" json:"selection_id,omitempty"`
+	Selection   string `protobuf:"bytes,10,opt,name=selection,proto3" json:"selection,omitempty"`
+	// The line of the market the odds were quoted at.
+	Line          *float64 `protobuf:"fixed64,11,opt,name=line,proto3,oneof" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateCoefficientResponse) Reset() {
@@ -165,27 +267,66 @@ func (x *UpdateCoefficientResponse) GetUpdatedAt() int64 {
 	return 0
 }
 
-type GetMarketCoefficientRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MarketId      uint32                 `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+func (x *UpdateCoefficientResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *UpdateCoefficientResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateCoefficientResponse) GetSelectionId() uint32 {
+	if x != nil {
+		return x.SelectionId
+	}
+	return 0
+}
+
+func (x *UpdateCoefficientResponse) GetSelection() string {
+	if x != nil {
+		return x.Selection
+	}
+	return ""
+}
+
+func (x *UpdateCoefficientResponse) GetLine() float64 {
+	if x != nil && x.Line != nil {
+		return *x.Line
+	}
+	return 0
+}
+
+// BatchUpdateCoefficientsRequest changes several selections of one event, for
+// example home/draw/away of a 1X2, in a single transaction: either every
+// change is applied or none is. A version conflict on any selection fails the
+// call with ABORTED and its market in the details.
+type BatchUpdateCoefficientsRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Changes       []*MarketCoefficientChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMarketCoefficientRequest) Reset() {
-	*x = GetMarketCoefficientRequest{}
+func (x *BatchUpdateCoefficientsRequest) Reset() {
+	*x = BatchUpdateCoefficientsRequest{}
 	mi := &file_proto_coefficient_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMarketCoefficientRequest) String() string {
+func (x *BatchUpdateCoefficientsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMarketCoefficientRequest) ProtoMessage() {}
+func (*BatchUpdateCoefficientsRequest) ProtoMessage() {}
 
-func (x *GetMarketCoefficientRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchUpdateCoefficientsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_coefficient_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -197,43 +338,45 @@ func (x *GetMarketCoefficientRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMarketCoefficientRequest.ProtoReflect.Descriptor instead.
-func (*GetMarketCoefficientRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchUpdateCoefficientsRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateCoefficientsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_coefficient_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetMarketCoefficientRequest) GetMarketId() uint32 {
+func (x *BatchUpdateCoefficientsRequest) GetChanges() []*MarketCoefficientChange {
 	if x != nil {
-		return x.MarketId
+		return x.Changes
 	}
-	return 0
+	return nil
 }
 
-type GetMarketCoefficientResponse struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	MarketId            uint32                 `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
-	CurrentCoefficient  float64                `protobuf:"fixed64,2,opt,name=current_coefficient,json=currentCoefficient,proto3" json:"current_coefficient,omitempty"`
-	PreviousCoefficient float64                `protobuf:"fixed64,3,opt,name=previous_coefficient,json=previousCoefficient,proto3" json:"previous_coefficient,omitempty"`
-	LastUpdated         int64                  `protobuf:"varint,4,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
-	Active              bool                   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type MarketCoefficientChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: use selection_id, see UpdateCoefficientRequest.
+	//
+	// Deprecated: Marked as deprecated in proto/coefficient.proto.
+	MarketId        uint32  `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	NewCoefficient  float64 `protobuf:"fixed64,2,opt,name=new_coefficient,json=newCoefficient,proto3" json:"new_coefficient,omitempty"`
+	ExpectedVersion *uint64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	SelectionId     uint32  `protobuf:"varint,4,opt,name=selection_id,json=selectionId,proto3" json:"selection_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GetMarketCoefficientResponse) Reset() {
-	*x = GetMarketCoefficientResponse{}
+func (x *MarketCoefficientChange) Reset() {
+	*x = MarketCoefficientChange{}
 	mi := &file_proto_coefficient_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMarketCoefficientResponse) String() string {
+func (x *MarketCoefficientChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMarketCoefficientResponse) ProtoMessage() {}
+func (*MarketCoefficientChange) ProtoMessage() {}
 
-func (x *GetMarketCoefficientResponse) ProtoReflect() protoreflect.Message {
+func (x *MarketCoefficientChange) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_coefficient_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -245,68 +388,68 @@ func (x *GetMarketCoefficientResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMarketCoefficientResponse.ProtoReflect.Descriptor instead.
-func (*GetMarketCoefficientResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MarketCoefficientChange.ProtoReflect.Descriptor instead.
+func (*MarketCoefficientChange) Descriptor() ([]byte, []int) {
 	return file_proto_coefficient_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetMarketCoefficientResponse) GetMarketId() uint32 {
+// Deprecated: Marked as deprecated in proto/coefficient.proto.
+func (x *MarketCoefficientChange) GetMarketId() uint32 {
 	if x != nil {
 		return x.MarketId
 	}
 	return 0
 }
 
-func (x *GetMarketCoefficientResponse) GetCurrentCoefficient() float64 {
+func (x *MarketCoefficientChange) GetNewCoefficient() float64 {
 	if x != nil {
-		return x.CurrentCoefficient
+		return x.NewCoefficient
 	}
 	return 0
 }
 
-func (x *GetMarketCoefficientResponse) GetPreviousCoefficient() float64 {
-	if x != nil {
-		return x.PreviousCoefficient
+func (x *MarketCoefficientChange) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
 	}
 	return 0
 }
 
-func (x *GetMarketCoefficientResponse) GetLastUpdated() int64 {
+func (x *MarketCoefficientChange) GetSelectionId() uint32 {
 	if x != nil {
-		return x.LastUpdated
+		return x.SelectionId
 	}
 	return 0
 }
 
-func (x *GetMarketCoefficientResponse) GetActive() bool {
-	if x != nil {
-		return x.Active
-	}
-	return false
-}
-
-type StreamCoefficientRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventIds      []uint32               `protobuf:"varint,1,rep,packed,name=event_ids,json=eventIds,proto3" json:"event_ids,omitempty"`
-	MarketIds     []uint32               `protobuf:"varint,2,rep,packed,name=market_ids,json=marketIds,proto3" json:"market_ids,omitempty"`
+// BatchUpdateCoefficientsResponse holds the result of every change. They
+// share the sequence and were published as one coefficient_batch_update.
+type BatchUpdateCoefficientsResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Success       bool                         `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                       `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	EventId       uint32                       `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Sequence      uint64                       `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	UpdatedAt     int64                        `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Updates       []*UpdateCoefficientResponse `protobuf:"bytes,6,rep,name=updates,proto3" json:"updates,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamCoefficientRequest) Reset() {
-	*x = StreamCoefficientRequest{}
+func (x *BatchUpdateCoefficientsResponse) Reset() {
+	*x = BatchUpdateCoefficientsResponse{}
 	mi := &file_proto_coefficient_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamCoefficientRequest) String() string {
+func (x *BatchUpdateCoefficientsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamCoefficientRequest) ProtoMessage() {}
+func (*BatchUpdateCoefficientsResponse) ProtoMessage() {}
 
-func (x *StreamCoefficientRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchUpdateCoefficientsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_coefficient_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -318,51 +461,85 @@ func (x *StreamCoefficientRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamCoefficientRequest.ProtoReflect.Descriptor instead.
-func (*StreamCoefficientRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchUpdateCoefficientsResponse.ProtoReflect.Descriptor instead.
+func (*BatchUpdateCoefficientsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_coefficient_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *StreamCoefficientRequest) GetEventIds() []uint32 {
+func (x *BatchUpdateCoefficientsResponse) GetSuccess() bool {
 	if x != nil {
-		return x.EventIds
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *StreamCoefficientRequest) GetMarketIds() []uint32 {
+func (x *BatchUpdateCoefficientsResponse) GetMessage() string {
 	if x != nil {
-		return x.MarketIds
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BatchUpdateCoefficientsResponse) GetEventId() uint32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *BatchUpdateCoefficientsResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *BatchUpdateCoefficientsResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *BatchUpdateCoefficientsResponse) GetUpdates() []*UpdateCoefficientResponse {
+	if x != nil {
+		return x.Updates
 	}
 	return nil
 }
 
-type CoefficientUpdateEvent struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Type           string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	MarketId       uint32                 `protobuf:"varint,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
-	EventId        uint32                 `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	OldCoefficient float64                `protobuf:"fixed64,4,opt,name=old_coefficient,json=oldCoefficient,proto3" json:"old_coefficient,omitempty"`
-	NewCoefficient float64                `protobuf:"fixed64,5,opt,name=new_coefficient,json=newCoefficient,proto3" json:"new_coefficient,omitempty"`
-	Timestamp      int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// IngestCoefficientsRequest is one update of an odds feed. With a single
+// change it is applied like UpdateCoefficient, with several like
+// BatchUpdateCoefficients.
+//
+// Requests of a stream are applied in the order they are sent and each is
+// answered by an IngestCoefficientsAck with the same feed_id. The server
+// reads at most the number of requests given in the ingest-window response
+// header ahead of the one it is applying; a feed that sends faster is held
+// back by gRPC flow control.
+type IngestCoefficientsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Chosen by the feed to match the ack to the request.
+	FeedId        uint64                     `protobuf:"varint,1,opt,name=feed_id,json=feedId,proto3" json:"feed_id,omitempty"`
+	Changes       []*MarketCoefficientChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CoefficientUpdateEvent) Reset() {
-	*x = CoefficientUpdateEvent{}
+func (x *IngestCoefficientsRequest) Reset() {
+	*x = IngestCoefficientsRequest{}
 	mi := &file_proto_coefficient_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CoefficientUpdateEvent) String() string {
+func (x *IngestCoefficientsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CoefficientUpdateEvent) ProtoMessage() {}
+func (*IngestCoefficientsRequest) ProtoMessage() {}
 
-func (x *CoefficientUpdateEvent) ProtoReflect() protoreflect.Message {
+func (x *IngestCoefficientsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_coefficient_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -374,93 +551,2025 @@ func (x *CoefficientUpdateEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CoefficientUpdateEvent.ProtoReflect.Descriptor instead.
-func (*CoefficientUpdateEvent) Descriptor() ([]byte, []int) {
+// Deprecated: Use IngestCoefficientsRequest.ProtoReflect.Descriptor instead.
+func (*IngestCoefficientsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_coefficient_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CoefficientUpdateEvent) GetType() string {
+func (x *IngestCoefficientsRequest) GetFeedId() uint64 {
 	if x != nil {
-		return x.Type
+		return x.FeedId
 	}
-	return ""
+	return 0
 }
 
-func (x *CoefficientUpdateEvent) GetMarketId() uint32 {
+func (x *IngestCoefficientsRequest) GetChanges() []*MarketCoefficientChange {
 	if x != nil {
-		return x.MarketId
+		return x.Changes
 	}
-	return 0
+	return nil
 }
 
-func (x *CoefficientUpdateEvent) GetEventId() uint32 {
+type IngestCoefficientsAck struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FeedId   uint64                 `protobuf:"varint,1,opt,name=feed_id,json=feedId,proto3" json:"feed_id,omitempty"`
+	Accepted bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// Set when the update was rejected, none of its changes were applied.
+	RejectReason IngestCoefficientsAck_RejectReason `protobuf:"varint,3,opt,name=reject_reason,json=rejectReason,proto3,enum=coefficient.IngestCoefficientsAck_RejectReason" json:"reject_reason,omitempty"`
+	Message      string                             `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Sequence     uint64                             `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Updates      []*UpdateCoefficientResponse       `protobuf:"bytes,6,rep,name=updates,proto3" json:"updates,omitempty"`
+	// The current market of the selection on VERSION_CONFLICT.
+	Current       *GetMarketCoefficientResponse `protobuf:"bytes,7,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestCoefficientsAck) Reset() {
+	*x = IngestCoefficientsAck{}
+	mi := &file_proto_coefficient_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestCoefficientsAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestCoefficientsAck) ProtoMessage() {}
+
+func (x *IngestCoefficientsAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[6]
 	if x != nil {
-		return x.EventId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CoefficientUpdateEvent) GetOldCoefficient() float64 {
+// Deprecated: Use IngestCoefficientsAck.ProtoReflect.Descriptor instead.
+func (*IngestCoefficientsAck) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IngestCoefficientsAck) GetFeedId() uint64 {
 	if x != nil {
-		return x.OldCoefficient
+		return x.FeedId
 	}
 	return 0
 }
 
-func (x *CoefficientUpdateEvent) GetNewCoefficient() float64 {
+func (x *IngestCoefficientsAck) GetAccepted() bool {
 	if x != nil {
-		return x.NewCoefficient
+		return x.Accepted
 	}
-	return 0
+	return false
 }
 
-func (x *CoefficientUpdateEvent) GetTimestamp() int64 {
+func (x *IngestCoefficientsAck) GetRejectReason() IngestCoefficientsAck_RejectReason {
 	if x != nil {
-		return x.Timestamp
+		return x.RejectReason
+	}
+	return IngestCoefficientsAck_REJECT_REASON_UNSPECIFIED
+}
+
+func (x *IngestCoefficientsAck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *IngestCoefficientsAck) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
 	}
 	return 0
 }
 
-var File_proto_coefficient_proto protoreflect.FileDescriptor
+func (x *IngestCoefficientsAck) GetUpdates() []*UpdateCoefficientResponse {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
 
-const file_proto_coefficient_proto_rawDesc = "" +
-	"\n" +
-	"\x17proto/coefficient.proto\x12\vcoefficient\"y\n" +
-	"\x18UpdateCoefficientRequest\x12\x1b\n" +
-	"\tmarket_id\x18\x01 \x01(\rR\bmarketId\x12'\n" +
-	"\x0fnew_coefficient\x18\x02 \x01(\x01R\x0enewCoefficient\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\rR\x06userId\"\xdd\x01\n" +
-	"\x19UpdateCoefficientResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
-	"\tmarket_id\x18\x03 \x01(\rR\bmarketId\x12'\n" +
-	"\x0fold_coefficient\x18\x04 \x01(\x01R\x0eoldCoefficient\x12'\n" +
-	"\x0fnew_coefficient\x18\x05 \x01(\x01R\x0enewCoefficient\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\":\n" +
-	"\x1bGetMarketCoefficientRequest\x12\x1b\n" +
-	"\tmarket_id\x18\x01 \x01(\rR\bmarketId\"\xda\x01\n" +
-	"\x1cGetMarketCoefficientResponse\x12\x1b\n" +
-	"\tmarket_id\x18\x01 \x01(\rR\bmarketId\x12/\n" +
-	"\x13current_coefficient\x18\x02 \x01(\x01R\x12currentCoefficient\x121\n" +
-	"\x14previous_coefficient\x18\x03 \x01(\x01R\x13previousCoefficient\x12!\n" +
-	"\flast_updated\x18\x04 \x01(\x03R\vlastUpdated\x12\x16\n" +
-	"\x06active\x18\x05 \x01(\bR\x06active\"V\n" +
-	"\x18StreamCoefficientRequest\x12\x1b\n" +
-	"\tevent_ids\x18\x01 \x03(\rR\beventIds\x12\x1d\n" +
-	"\n" +
-	"market_ids\x18\x02 \x03(\rR\tmarketIds\"\xd4\x01\n" +
-	"\x16CoefficientUpdateEvent\x12\x12\n" +
-	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1b\n" +
-	"\tmarket_id\x18\x02 \x01(\rR\bmarketId\x12\x19\n" +
-	"\bevent_id\x18\x03 \x01(\rR\aeventId\x12'\n" +
-	"\x0fold_coefficient\x18\x04 \x01(\x01R\x0eoldCoefficient\x12'\n" +
-	"\x0fnew_coefficient\x18\x05 \x01(\x01R\x0enewCoefficient\x12\x1c\n" +
-	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp2\xcf\x02\n" +
+func (x *IngestCoefficientsAck) GetCurrent() *GetMarketCoefficientResponse {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+type GetMarketCoefficientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MarketId      uint32                 `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMarketCoefficientRequest) Reset() {
+	*x = GetMarketCoefficientRequest{}
+	mi := &file_proto_coefficient_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMarketCoefficientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMarketCoefficientRequest) ProtoMessage() {}
+
+func (x *GetMarketCoefficientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMarketCoefficientRequest.ProtoReflect.Descriptor instead.
+func (*GetMarketCoefficientRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMarketCoefficientRequest) GetMarketId() uint32 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+type GetMarketCoefficientResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MarketId uint32                 `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	// Deprecated: see selections. Set for markets with a single selection.
+	//
+	// Deprecated: Marked as deprecated in proto/coefficient.proto.
+	CurrentCoefficient float64 `protobuf:"fixed64,2,opt,name=current_coefficient,json=currentCoefficient,proto3" json:"current_coefficient,omitempty"`
+	// Deprecated: Marked as deprecated in proto/coefficient.proto.
+	PreviousCoefficient float64 `protobuf:"fixed64,3,opt,name=previous_coefficient,json=previousCoefficient,proto3" json:"previous_coefficient,omitempty"`
+	// Deprecated: Marked as deprecated in proto/coefficient.proto.
+	LastUpdated int64 `protobuf:"varint,4,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	// Deprecated: status is open.
+	Active     bool         `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	Version    uint64       `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	Status     string       `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Selections []*Selection `protobuf:"bytes,8,rep,name=selections,proto3" json:"selections,omitempty"`
+	Type       string       `protobuf:"bytes,9,opt,name=type,proto3" json:"type,omitempty"`
+	// Market parameters, see MarketSnapshot.
+	Line          *float64 `protobuf:"fixed64,10,opt,name=line,proto3,oneof" json:"line,omitempty"`
+	Period        string   `protobuf:"bytes,11,opt,name=period,proto3" json:"period,omitempty"`
+	Player        string   `protobuf:"bytes,12,opt,name=player,proto3" json:"player,omitempty"`
+	TeamId        *uint32  `protobuf:"varint,13,opt,name=team_id,json=teamId,proto3,oneof" json:"team_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMarketCoefficientResponse) Reset() {
+	*x = GetMarketCoefficientResponse{}
+	mi := &file_proto_coefficient_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMarketCoefficientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMarketCoefficientResponse) ProtoMessage() {}
+
+func (x *GetMarketCoefficientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMarketCoefficientResponse.ProtoReflect.Descriptor instead.
+func (*GetMarketCoefficientResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetMarketCoefficientResponse) GetMarketId() uint32 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/coefficient.proto.
+func (x *GetMarketCoefficientResponse) GetCurrentCoefficient() float64 {
+	if x != nil {
+		return x.CurrentCoefficient
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/coefficient.proto.
+func (x *GetMarketCoefficientResponse) GetPreviousCoefficient() float64 {
+	if x != nil {
+		return x.PreviousCoefficient
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/coefficient.proto.
+func (x *GetMarketCoefficientResponse) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+func (x *GetMarketCoefficientResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *GetMarketCoefficientResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetMarketCoefficientResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetMarketCoefficientResponse) GetSelections() []*Selection {
+	if x != nil {
+		return x.Selections
+	}
+	return nil
+}
+
+func (x *GetMarketCoefficientResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetMarketCoefficientResponse) GetLine() float64 {
+	if x != nil && x.Line != nil {
+		return *x.Line
+	}
+	return 0
+}
+
+func (x *GetMarketCoefficientResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *GetMarketCoefficientResponse) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *GetMarketCoefficientResponse) GetTeamId() uint32 {
+	if x != nil && x.TeamId != nil {
+		return *x.TeamId
+	}
+	return 0
+}
+
+// Selection is a priced outcome of a market, such as the home win of a match
+// winner market.
+type Selection struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	SelectionId         uint32                 `protobuf:"varint,1,opt,name=selection_id,json=selectionId,proto3" json:"selection_id,omitempty"`
+	MarketId            uint32                 `protobuf:"varint,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Name                string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CurrentCoefficient  float64                `protobuf:"fixed64,4,opt,name=current_coefficient,json=currentCoefficient,proto3" json:"current_coefficient,omitempty"`
+	PreviousCoefficient float64                `protobuf:"fixed64,5,opt,name=previous_coefficient,json=previousCoefficient,proto3" json:"previous_coefficient,omitempty"`
+	LastUpdated         int64                  `protobuf:"varint,6,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Version             uint64                 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	MinCoefficient      float64                `protobuf:"fixed64,8,opt,name=min_coefficient,json=minCoefficient,proto3" json:"min_coefficient,omitempty"`
+	MaxCoefficient      float64                `protobuf:"fixed64,9,opt,name=max_coefficient,json=maxCoefficient,proto3" json:"max_coefficient,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Selection) Reset() {
+	*x = Selection{}
+	mi := &file_proto_coefficient_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Selection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Selection) ProtoMessage() {}
+
+func (x *Selection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Selection.ProtoReflect.Descriptor instead.
+func (*Selection) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Selection) GetSelectionId() uint32 {
+	if x != nil {
+		return x.SelectionId
+	}
+	return 0
+}
+
+func (x *Selection) GetMarketId() uint32 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *Selection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Selection) GetCurrentCoefficient() float64 {
+	if x != nil {
+		return x.CurrentCoefficient
+	}
+	return 0
+}
+
+func (x *Selection) GetPreviousCoefficient() float64 {
+	if x != nil {
+		return x.PreviousCoefficient
+	}
+	return 0
+}
+
+func (x *Selection) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+func (x *Selection) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Selection) GetMinCoefficient() float64 {
+	if x != nil {
+		return x.MinCoefficient
+	}
+	return 0
+}
+
+func (x *Selection) GetMaxCoefficient() float64 {
+	if x != nil {
+		return x.MaxCoefficient
+	}
+	return 0
+}
+
+// SetMarketStatusRequest moves a market between statuses: open, suspended,
+// closed, settled and voided. Odds can only be updated while it is open.
+// Transitions that are not allowed fail with FAILED_PRECONDITION.
+type SetMarketStatusRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MarketId uint32                 `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Status   string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Published with the market_status message.
+	Reason          string  `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpectedVersion *uint64 `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetMarketStatusRequest) Reset() {
+	*x = SetMarketStatusRequest{}
+	mi := &file_proto_coefficient_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMarketStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMarketStatusRequest) ProtoMessage() {}
+
+func (x *SetMarketStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMarketStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetMarketStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetMarketStatusRequest) GetMarketId() uint32 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *SetMarketStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SetMarketStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SetMarketStatusRequest) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type SetMarketStatusResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	MarketId       uint32                 `protobuf:"varint,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,4,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	UpdatedAt      int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Sequence       uint64                 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version        uint64                 `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetMarketStatusResponse) Reset() {
+	*x = SetMarketStatusResponse{}
+	mi := &file_proto_coefficient_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMarketStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMarketStatusResponse) ProtoMessage() {}
+
+func (x *SetMarketStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMarketStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetMarketStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetMarketStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetMarketStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetMarketStatusResponse) GetMarketId() uint32 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *SetMarketStatusResponse) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *SetMarketStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SetMarketStatusResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *SetMarketStatusResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *SetMarketStatusResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// SetEventStatusRequest moves an event through its lifecycle: scheduled,
+// pre_match, live, half_time and finished, or postponed and cancelled.
+// Going live or being postponed suspends the open markets of the event,
+// finishing closes them and cancelling voids them.
+// MoveMarketLineRequest moves the line of an over_under, handicap, team_total
+// or player_goals market, with the odds of its selections quoted at the new
+// line. The move and the odds are applied together and published as one
+// market_line message. The market must be open.
+type MoveMarketLineRequest struct {
+	state        protoimpl.MessageState     `protogen:"open.v1"`
+	MarketId     uint32                     `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Line         float64                    `protobuf:"fixed64,2,opt,name=line,proto3" json:"line,omitempty"`
+	Coefficients []*MarketCoefficientChange `protobuf:"bytes,3,rep,name=coefficients,proto3" json:"coefficients,omitempty"`
+	// The version of the market, see UpdateCoefficientRequest.
+	ExpectedVersion *uint64 `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MoveMarketLineRequest) Reset() {
+	*x = MoveMarketLineRequest{}
+	mi := &file_proto_coefficient_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveMarketLineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveMarketLineRequest) ProtoMessage() {}
+
+func (x *MoveMarketLineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveMarketLineRequest.ProtoReflect.Descriptor instead.
+func (*MoveMarketLineRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MoveMarketLineRequest) GetMarketId() uint32 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *MoveMarketLineRequest) GetLine() float64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *MoveMarketLineRequest) GetCoefficients() []*MarketCoefficientChange {
+	if x != nil {
+		return x.Coefficients
+	}
+	return nil
+}
+
+func (x *MoveMarketLineRequest) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type MoveMarketLineResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Success  bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message  string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	MarketId uint32                 `protobuf:"varint,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	// Not set for the first line of a market.
+	PreviousLine  *float64                     `protobuf:"fixed64,4,opt,name=previous_line,json=previousLine,proto3,oneof" json:"previous_line,omitempty"`
+	Line          float64                      `protobuf:"fixed64,5,opt,name=line,proto3" json:"line,omitempty"`
+	UpdatedAt     int64                        `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Sequence      uint64                       `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version       uint64                       `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	Updates       []*UpdateCoefficientResponse `protobuf:"bytes,9,rep,name=updates,proto3" json:"updates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveMarketLineResponse) Reset() {
+	*x = MoveMarketLineResponse{}
+	mi := &file_proto_coefficient_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveMarketLineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveMarketLineResponse) ProtoMessage() {}
+
+func (x *MoveMarketLineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveMarketLineResponse.ProtoReflect.Descriptor instead.
+func (*MoveMarketLineResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MoveMarketLineResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *MoveMarketLineResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MoveMarketLineResponse) GetMarketId() uint32 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *MoveMarketLineResponse) GetPreviousLine() float64 {
+	if x != nil && x.PreviousLine != nil {
+		return *x.PreviousLine
+	}
+	return 0
+}
+
+func (x *MoveMarketLineResponse) GetLine() float64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *MoveMarketLineResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *MoveMarketLineResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *MoveMarketLineResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MoveMarketLineResponse) GetUpdates() []*UpdateCoefficientResponse {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+type SetEventStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint32                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEventStatusRequest) Reset() {
+	*x = SetEventStatusRequest{}
+	mi := &file_proto_coefficient_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEventStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEventStatusRequest) ProtoMessage() {}
+
+func (x *SetEventStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEventStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetEventStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetEventStatusRequest) GetEventId() uint32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SetEventStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SetEventStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SetEventStatusResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	EventId        uint32                 `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,4,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	IsLive         bool                   `protobuf:"varint,6,opt,name=is_live,json=isLive,proto3" json:"is_live,omitempty"`
+	UpdatedAt      int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Sequence       uint64                 `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The markets whose status changed with the event.
+	Markets       []*SetMarketStatusResponse `protobuf:"bytes,9,rep,name=markets,proto3" json:"markets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEventStatusResponse) Reset() {
+	*x = SetEventStatusResponse{}
+	mi := &file_proto_coefficient_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEventStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEventStatusResponse) ProtoMessage() {}
+
+func (x *SetEventStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEventStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetEventStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetEventStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetEventStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetEventStatusResponse) GetEventId() uint32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SetEventStatusResponse) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *SetEventStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SetEventStatusResponse) GetIsLive() bool {
+	if x != nil {
+		return x.IsLive
+	}
+	return false
+}
+
+func (x *SetEventStatusResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *SetEventStatusResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *SetEventStatusResponse) GetMarkets() []*SetMarketStatusResponse {
+	if x != nil {
+		return x.Markets
+	}
+	return nil
+}
+
+// RecordIncidentRequest records a match incident of a live or half_time event:
+// goal, card, penalty and corner of a team, or period_start and period_end.
+// Goals update the score. Incidents of other events fail with
+// FAILED_PRECONDITION.
+type RecordIncidentRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId uint32                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Type    string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Required for team incidents, must not be set for period incidents.
+	TeamId *uint32 `protobuf:"varint,3,opt,name=team_id,json=teamId,proto3,oneof" json:"team_id,omitempty"`
+	// first_half, second_half, extra_time or penalties.
+	Period string `protobuf:"bytes,4,opt,name=period,proto3" json:"period,omitempty"`
+	Minute uint32 `protobuf:"varint,5,opt,name=minute,proto3" json:"minute,omitempty"`
+	Player string `protobuf:"bytes,6,opt,name=player,proto3" json:"player,omitempty"`
+	// yellow or red for cards.
+	Detail        string `protobuf:"bytes,7,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordIncidentRequest) Reset() {
+	*x = RecordIncidentRequest{}
+	mi := &file_proto_coefficient_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordIncidentRequest) ProtoMessage() {}
+
+func (x *RecordIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordIncidentRequest.ProtoReflect.Descriptor instead.
+func (*RecordIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RecordIncidentRequest) GetEventId() uint32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *RecordIncidentRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RecordIncidentRequest) GetTeamId() uint32 {
+	if x != nil && x.TeamId != nil {
+		return *x.TeamId
+	}
+	return 0
+}
+
+func (x *RecordIncidentRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *RecordIncidentRequest) GetMinute() uint32 {
+	if x != nil {
+		return x.Minute
+	}
+	return 0
+}
+
+func (x *RecordIncidentRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *RecordIncidentRequest) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type TeamScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamId        uint32                 `protobuf:"varint,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Goals         int32                  `protobuf:"varint,3,opt,name=goals,proto3" json:"goals,omitempty"`
+	Periods       map[string]int32       `protobuf:"bytes,4,rep,name=periods,proto3" json:"periods,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamScore) Reset() {
+	*x = TeamScore{}
+	mi := &file_proto_coefficient_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamScore) ProtoMessage() {}
+
+func (x *TeamScore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamScore.ProtoReflect.Descriptor instead.
+func (*TeamScore) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TeamScore) GetTeamId() uint32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *TeamScore) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TeamScore) GetGoals() int32 {
+	if x != nil {
+		return x.Goals
+	}
+	return 0
+}
+
+func (x *TeamScore) GetPeriods() map[string]int32 {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+type Incident struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type     string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	TeamId   uint32                 `protobuf:"varint,3,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	TeamName string                 `protobuf:"bytes,4,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Period   string                 `protobuf:"bytes,5,opt,name=period,proto3" json:"period,omitempty"`
+	Minute   uint32                 `protobuf:"varint,6,opt,name=minute,proto3" json:"minute,omitempty"`
+	Player   string                 `protobuf:"bytes,7,opt,name=player,proto3" json:"player,omitempty"`
+	Detail   string                 `protobuf:"bytes,8,opt,name=detail,proto3" json:"detail,omitempty"`
+	// The score after the incident.
+	Score         []*TeamScore `protobuf:"bytes,9,rep,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Incident) Reset() {
+	*x = Incident{}
+	mi := &file_proto_coefficient_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Incident) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Incident) ProtoMessage() {}
+
+func (x *Incident) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Incident.ProtoReflect.Descriptor instead.
+func (*Incident) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Incident) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Incident) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Incident) GetTeamId() uint32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *Incident) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Incident) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *Incident) GetMinute() uint32 {
+	if x != nil {
+		return x.Minute
+	}
+	return 0
+}
+
+func (x *Incident) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *Incident) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *Incident) GetScore() []*TeamScore {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+type RecordIncidentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	EventId       uint32                 `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Incident      *Incident              `protobuf:"bytes,4,opt,name=incident,proto3" json:"incident,omitempty"`
+	RecordedAt    int64                  `protobuf:"varint,5,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordIncidentResponse) Reset() {
+	*x = RecordIncidentResponse{}
+	mi := &file_proto_coefficient_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordIncidentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordIncidentResponse) ProtoMessage() {}
+
+func (x *RecordIncidentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordIncidentResponse.ProtoReflect.Descriptor instead.
+func (*RecordIncidentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RecordIncidentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RecordIncidentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RecordIncidentResponse) GetEventId() uint32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *RecordIncidentResponse) GetIncident() *Incident {
+	if x != nil {
+		return x.Incident
+	}
+	return nil
+}
+
+func (x *RecordIncidentResponse) GetRecordedAt() int64 {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return 0
+}
+
+func (x *RecordIncidentResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type StreamCoefficientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventIds      []uint32               `protobuf:"varint,1,rep,packed,name=event_ids,json=eventIds,proto3" json:"event_ids,omitempty"`
+	MarketIds     []uint32               `protobuf:"varint,2,rep,packed,name=market_ids,json=marketIds,proto3" json:"market_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamCoefficientRequest) Reset() {
+	*x = StreamCoefficientRequest{}
+	mi := &file_proto_coefficient_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamCoefficientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamCoefficientRequest) ProtoMessage() {}
+
+func (x *StreamCoefficientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamCoefficientRequest.ProtoReflect.Descriptor instead.
+func (*StreamCoefficientRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StreamCoefficientRequest) GetEventIds() []uint32 {
+	if x != nil {
+		return x.EventIds
+	}
+	return nil
+}
+
+func (x *StreamCoefficientRequest) GetMarketIds() []uint32 {
+	if x != nil {
+		return x.MarketIds
+	}
+	return nil
+}
+
+type CoefficientUpdateEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// The market of the selection on coefficient_update events.
+	MarketId       uint32  `protobuf:"varint,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	EventId        uint32  `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	OldCoefficient float64 `protobuf:"fixed64,4,opt,name=old_coefficient,json=oldCoefficient,proto3" json:"old_coefficient,omitempty"`
+	NewCoefficient float64 `protobuf:"fixed64,5,opt,name=new_coefficient,json=newCoefficient,proto3" json:"new_coefficient,omitempty"`
+	Timestamp      int64   `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Per event, increasing with every update. See EventSnapshot. The markets
+	// of a batch update share one sequence.
+	Sequence uint64 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The new market status on market_status events and the new event status
+	// on event_status events, which have no market_id.
+	Status string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	// Set on incident events, which have no market_id.
+	Incident    *Incident `protobuf:"bytes,9,opt,name=incident,proto3" json:"incident,omitempty"`
+	SelectionId uint32    `protobuf:"varint,10,opt,name=selection_id,json=selectionId,proto3" json:"selection_id,omitempty"`
+	// The line of the market on coefficient_update events and the new line on
+	// market_line events, which carry their odds as coefficient_update events
+	// with the same sequence.
+	Line          *float64 `protobuf:"fixed64,11,opt,name=line,proto3,oneof" json:"line,omitempty"`
+	PreviousLine  *float64 `protobuf:"fixed64,12,opt,name=previous_line,json=previousLine,proto3,oneof" json:"previous_line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoefficientUpdateEvent) Reset() {
+	*x = CoefficientUpdateEvent{}
+	mi := &file_proto_coefficient_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoefficientUpdateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoefficientUpdateEvent) ProtoMessage() {}
+
+func (x *CoefficientUpdateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoefficientUpdateEvent.ProtoReflect.Descriptor instead.
+func (*CoefficientUpdateEvent) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CoefficientUpdateEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CoefficientUpdateEvent) GetMarketId() uint32 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *CoefficientUpdateEvent) GetEventId() uint32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *CoefficientUpdateEvent) GetOldCoefficient() float64 {
+	if x != nil {
+		return x.OldCoefficient
+	}
+	return 0
+}
+
+func (x *CoefficientUpdateEvent) GetNewCoefficient() float64 {
+	if x != nil {
+		return x.NewCoefficient
+	}
+	return 0
+}
+
+func (x *CoefficientUpdateEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *CoefficientUpdateEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *CoefficientUpdateEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CoefficientUpdateEvent) GetIncident() *Incident {
+	if x != nil {
+		return x.Incident
+	}
+	return nil
+}
+
+func (x *CoefficientUpdateEvent) GetSelectionId() uint32 {
+	if x != nil {
+		return x.SelectionId
+	}
+	return 0
+}
+
+func (x *CoefficientUpdateEvent) GetLine() float64 {
+	if x != nil && x.Line != nil {
+		return *x.Line
+	}
+	return 0
+}
+
+func (x *CoefficientUpdateEvent) GetPreviousLine() float64 {
+	if x != nil && x.PreviousLine != nil {
+		return *x.PreviousLine
+	}
+	return 0
+}
+
+type GetEventSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint32                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventSnapshotRequest) Reset() {
+	*x = GetEventSnapshotRequest{}
+	mi := &file_proto_coefficient_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventSnapshotRequest) ProtoMessage() {}
+
+func (x *GetEventSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetEventSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetEventSnapshotRequest) GetEventId() uint32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type MarketSnapshot struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MarketId uint32                 `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type     string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// Deprecated: see selections. Set for markets with a single selection.
+	//
+	// Deprecated: Marked as deprecated in proto/coefficient.proto.
+	CurrentCoefficient float64 `protobuf:"fixed64,4,opt,name=current_coefficient,json=currentCoefficient,proto3" json:"current_coefficient,omitempty"`
+	// Deprecated: Marked as deprecated in proto/coefficient.proto.
+	PreviousCoefficient float64 `protobuf:"fixed64,5,opt,name=previous_coefficient,json=previousCoefficient,proto3" json:"previous_coefficient,omitempty"`
+	// Deprecated: Marked as deprecated in proto/coefficient.proto.
+	LastUpdated int64        `protobuf:"varint,6,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Status      string       `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Selections  []*Selection `protobuf:"bytes,8,rep,name=selections,proto3" json:"selections,omitempty"`
+	// The line of over_under, handicap, team_total and player_goals markets,
+	// the period the market is limited to, the player of player_goals and the
+	// team of handicap and team_total markets.
+	Line          *float64 `protobuf:"fixed64,9,opt,name=line,proto3,oneof" json:"line,omitempty"`
+	Period        string   `protobuf:"bytes,10,opt,name=period,proto3" json:"period,omitempty"`
+	Player        string   `protobuf:"bytes,11,opt,name=player,proto3" json:"player,omitempty"`
+	TeamId        *uint32  `protobuf:"varint,12,opt,name=team_id,json=teamId,proto3,oneof" json:"team_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketSnapshot) Reset() {
+	*x = MarketSnapshot{}
+	mi := &file_proto_coefficient_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketSnapshot) ProtoMessage() {}
+
+func (x *MarketSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketSnapshot.ProtoReflect.Descriptor instead.
+func (*MarketSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MarketSnapshot) GetMarketId() uint32 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MarketSnapshot) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in proto/coefficient.proto.
+func (x *MarketSnapshot) GetCurrentCoefficient() float64 {
+	if x != nil {
+		return x.CurrentCoefficient
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/coefficient.proto.
+func (x *MarketSnapshot) GetPreviousCoefficient() float64 {
+	if x != nil {
+		return x.PreviousCoefficient
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/coefficient.proto.
+func (x *MarketSnapshot) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MarketSnapshot) GetSelections() []*Selection {
+	if x != nil {
+		return x.Selections
+	}
+	return nil
+}
+
+func (x *MarketSnapshot) GetLine() float64 {
+	if x != nil && x.Line != nil {
+		return *x.Line
+	}
+	return 0
+}
+
+func (x *MarketSnapshot) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *MarketSnapshot) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *MarketSnapshot) GetTeamId() uint32 {
+	if x != nil && x.TeamId != nil {
+		return *x.TeamId
+	}
+	return 0
+}
+
+// EventSnapshot holds the open and suspended markets of an event as of update
+// sequence. Updates with a greater sequence are applied on top of it.
+type EventSnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint32                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	CompetitionId uint32                 `protobuf:"varint,2,opt,name=competition_id,json=competitionId,proto3" json:"competition_id,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Markets       []*MarketSnapshot      `protobuf:"bytes,4,rep,name=markets,proto3" json:"markets,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	IsLive        bool                   `protobuf:"varint,7,opt,name=is_live,json=isLive,proto3" json:"is_live,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventSnapshot) Reset() {
+	*x = EventSnapshot{}
+	mi := &file_proto_coefficient_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventSnapshot) ProtoMessage() {}
+
+func (x *EventSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coefficient_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventSnapshot.ProtoReflect.Descriptor instead.
+func (*EventSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_coefficient_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EventSnapshot) GetEventId() uint32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *EventSnapshot) GetCompetitionId() uint32 {
+	if x != nil {
+		return x.CompetitionId
+	}
+	return 0
+}
+
+func (x *EventSnapshot) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *EventSnapshot) GetMarkets() []*MarketSnapshot {
+	if x != nil {
+		return x.Markets
+	}
+	return nil
+}
+
+func (x *EventSnapshot) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *EventSnapshot) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EventSnapshot) GetIsLive() bool {
+	if x != nil {
+		return x.IsLive
+	}
+	return false
+}
+
+var File_proto_coefficient_proto protoreflect.FileDescriptor
+
+const file_proto_coefficient_proto_rawDesc = "" +
+	"\n" +
+	"\x17proto/coefficient.proto\x12\vcoefficient\"\xe9\x01\n" +
+	"\x18UpdateCoefficientRequest\x12\x1f\n" +
+	"\tmarket_id\x18\x01 \x01(\rB\x02\x18\x01R\bmarketId\x12'\n" +
+	"\x0fnew_coefficient\x18\x02 \x01(\x01R\x0enewCoefficient\x12\x1b\n" +
+	"\auser_id\x18\x03 \x01(\rB\x02\x18\x01R\x06userId\x12.\n" +
+	"\x10expected_version\x18\x04 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01\x12!\n" +
+	"\fselection_id\x18\x05 \x01(\rR\vselectionIdB\x13\n" +
+	"\x11_expected_version\"\xf6\x02\n" +
+	"\x19UpdateCoefficientResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tmarket_id\x18\x03 \x01(\rR\bmarketId\x12'\n" +
+	"\x0fold_coefficient\x18\x04 \x01(\x01R\x0eoldCoefficient\x12'\n" +
+	"\x0fnew_coefficient\x18\x05 \x01(\x01R\x0enewCoefficient\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\x12\x1a\n" +
+	"\bsequence\x18\a \x01(\x04R\bsequence\x12\x18\n" +
+	"\aversion\x18\b \x01(\x04R\aversion\x12!\n" +
+	"\fselection_id\x18\t \x01(\rR\vselectionId\x12\x1c\n" +
+	"\tselection\x18\n" +
+	" \x01(\tR\tselection\x12\x17\n" +
+	"\x04line\x18\v \x01(\x01H\x00R\x04line\x88\x01\x01B\a\n" +
+	"\x05_line\"`\n" +
+	"\x1eBatchUpdateCoefficientsRequest\x12>\n" +
+	"\achanges\x18\x01 \x03(\v2$.coefficient.MarketCoefficientChangeR\achanges\"\xcb\x01\n" +
+	"\x17MarketCoefficientChange\x12\x1f\n" +
+	"\tmarket_id\x18\x01 \x01(\rB\x02\x18\x01R\bmarketId\x12'\n" +
+	"\x0fnew_coefficient\x18\x02 \x01(\x01R\x0enewCoefficient\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01\x12!\n" +
+	"\fselection_id\x18\x04 \x01(\rR\vselectionIdB\x13\n" +
+	"\x11_expected_version\"\xed\x01\n" +
+	"\x1fBatchUpdateCoefficientsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\rR\aeventId\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x04R\bsequence\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x12@\n" +
+	"\aupdates\x18\x06 \x03(\v2&.coefficient.UpdateCoefficientResponseR\aupdates\"t\n" +
+	"\x19IngestCoefficientsRequest\x12\x17\n" +
+	"\afeed_id\x18\x01 \x01(\x04R\x06feedId\x12>\n" +
+	"\achanges\x18\x02 \x03(\v2$.coefficient.MarketCoefficientChangeR\achanges\"\xae\x04\n" +
+	"\x15IngestCoefficientsAck\x12\x17\n" +
+	"\afeed_id\x18\x01 \x01(\x04R\x06feedId\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\x12T\n" +
+	"\rreject_reason\x18\x03 \x01(\x0e2/.coefficient.IngestCoefficientsAck.RejectReasonR\frejectReason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\x04R\bsequence\x12@\n" +
+	"\aupdates\x18\x06 \x03(\v2&.coefficient.UpdateCoefficientResponseR\aupdates\x12C\n" +
+	"\acurrent\x18\a \x01(\v2).coefficient.GetMarketCoefficientResponseR\acurrent\"\xcc\x01\n" +
+	"\fRejectReason\x12\x1d\n" +
+	"\x19REJECT_REASON_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aINVALID\x10\x01\x12\x14\n" +
+	"\x10MARKET_NOT_FOUND\x10\x02\x12\x11\n" +
+	"\rOUT_OF_BOUNDS\x10\x03\x12\x15\n" +
+	"\x11PERMISSION_DENIED\x10\x04\x12\x14\n" +
+	"\x10VERSION_CONFLICT\x10\x05\x12\f\n" +
+	"\bINTERNAL\x10\x06\x12\x13\n" +
+	"\x0fMARKET_NOT_OPEN\x10\a\x12\x17\n" +
+	"\x13SELECTION_NOT_FOUND\x10\b\":\n" +
+	"\x1bGetMarketCoefficientRequest\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\rR\bmarketId\"\xe0\x03\n" +
+	"\x1cGetMarketCoefficientResponse\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\rR\bmarketId\x123\n" +
+	"\x13current_coefficient\x18\x02 \x01(\x01B\x02\x18\x01R\x12currentCoefficient\x125\n" +
+	"\x14previous_coefficient\x18\x03 \x01(\x01B\x02\x18\x01R\x13previousCoefficient\x12%\n" +
+	"\flast_updated\x18\x04 \x01(\x03B\x02\x18\x01R\vlastUpdated\x12\x16\n" +
+	"\x06active\x18\x05 \x01(\bR\x06active\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x04R\aversion\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x126\n" +
+	"\n" +
+	"selections\x18\b \x03(\v2\x16.coefficient.SelectionR\n" +
+	"selections\x12\x12\n" +
+	"\x04type\x18\t \x01(\tR\x04type\x12\x17\n" +
+	"\x04line\x18\n" +
+	" \x01(\x01H\x00R\x04line\x88\x01\x01\x12\x16\n" +
+	"\x06period\x18\v \x01(\tR\x06period\x12\x16\n" +
+	"\x06player\x18\f \x01(\tR\x06player\x12\x1c\n" +
+	"\ateam_id\x18\r \x01(\rH\x01R\x06teamId\x88\x01\x01B\a\n" +
+	"\x05_lineB\n" +
+	"\n" +
+	"\b_team_id\"\xd2\x02\n" +
+	"\tSelection\x12!\n" +
+	"\fselection_id\x18\x01 \x01(\rR\vselectionId\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\rR\bmarketId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12/\n" +
+	"\x13current_coefficient\x18\x04 \x01(\x01R\x12currentCoefficient\x121\n" +
+	"\x14previous_coefficient\x18\x05 \x01(\x01R\x13previousCoefficient\x12!\n" +
+	"\flast_updated\x18\x06 \x01(\x03R\vlastUpdated\x12\x18\n" +
+	"\aversion\x18\a \x01(\x04R\aversion\x12'\n" +
+	"\x0fmin_coefficient\x18\b \x01(\x01R\x0eminCoefficient\x12'\n" +
+	"\x0fmax_coefficient\x18\t \x01(\x01R\x0emaxCoefficient\"\xaa\x01\n" +
+	"\x16SetMarketStatusRequest\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\rR\bmarketId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12.\n" +
+	"\x10expected_version\x18\x04 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x80\x02\n" +
+	"\x17SetMarketStatusResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tmarket_id\x18\x03 \x01(\rR\bmarketId\x12'\n" +
+	"\x0fprevious_status\x18\x04 \x01(\tR\x0epreviousStatus\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\x12\x1a\n" +
+	"\bsequence\x18\a \x01(\x04R\bsequence\x12\x18\n" +
+	"\aversion\x18\b \x01(\x04R\aversion\"\xd7\x01\n" +
+	"\x15MoveMarketLineRequest\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\rR\bmarketId\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x01R\x04line\x12H\n" +
+	"\fcoefficients\x18\x03 \x03(\v2$.coefficient.MarketCoefficientChangeR\fcoefficients\x12.\n" +
+	"\x10expected_version\x18\x04 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\xd0\x02\n" +
+	"\x16MoveMarketLineResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tmarket_id\x18\x03 \x01(\rR\bmarketId\x12(\n" +
+	"\rprevious_line\x18\x04 \x01(\x01H\x00R\fpreviousLine\x88\x01\x01\x12\x12\n" +
+	"\x04line\x18\x05 \x01(\x01R\x04line\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\x12\x1a\n" +
+	"\bsequence\x18\a \x01(\x04R\bsequence\x12\x18\n" +
+	"\aversion\x18\b \x01(\x04R\aversion\x12@\n" +
+	"\aupdates\x18\t \x03(\v2&.coefficient.UpdateCoefficientResponseR\aupdatesB\x10\n" +
+	"\x0e_previous_line\"b\n" +
+	"\x15SetEventStatusRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\rR\aeventId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xbc\x02\n" +
+	"\x16SetEventStatusResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\rR\aeventId\x12'\n" +
+	"\x0fprevious_status\x18\x04 \x01(\tR\x0epreviousStatus\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x17\n" +
+	"\ais_live\x18\x06 \x01(\bR\x06isLive\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\x12\x1a\n" +
+	"\bsequence\x18\b \x01(\x04R\bsequence\x12>\n" +
+	"\amarkets\x18\t \x03(\v2$.coefficient.SetMarketStatusResponseR\amarkets\"\xd0\x01\n" +
+	"\x15RecordIncidentRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\rR\aeventId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1c\n" +
+	"\ateam_id\x18\x03 \x01(\rH\x00R\x06teamId\x88\x01\x01\x12\x16\n" +
+	"\x06period\x18\x04 \x01(\tR\x06period\x12\x16\n" +
+	"\x06minute\x18\x05 \x01(\rR\x06minute\x12\x16\n" +
+	"\x06player\x18\x06 \x01(\tR\x06player\x12\x16\n" +
+	"\x06detail\x18\a \x01(\tR\x06detailB\n" +
+	"\n" +
+	"\b_team_id\"\xc9\x01\n" +
+	"\tTeamScore\x12\x17\n" +
+	"\ateam_id\x18\x01 \x01(\rR\x06teamId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05goals\x18\x03 \x01(\x05R\x05goals\x12=\n" +
+	"\aperiods\x18\x04 \x03(\v2#.coefficient.TeamScore.PeriodsEntryR\aperiods\x1a:\n" +
+	"\fPeriodsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xf2\x01\n" +
+	"\bIncident\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x17\n" +
+	"\ateam_id\x18\x03 \x01(\rR\x06teamId\x12\x1b\n" +
+	"\tteam_name\x18\x04 \x01(\tR\bteamName\x12\x16\n" +
+	"\x06period\x18\x05 \x01(\tR\x06period\x12\x16\n" +
+	"\x06minute\x18\x06 \x01(\rR\x06minute\x12\x16\n" +
+	"\x06player\x18\a \x01(\tR\x06player\x12\x16\n" +
+	"\x06detail\x18\b \x01(\tR\x06detail\x12,\n" +
+	"\x05score\x18\t \x03(\v2\x16.coefficient.TeamScoreR\x05score\"\xd7\x01\n" +
+	"\x16RecordIncidentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\rR\aeventId\x121\n" +
+	"\bincident\x18\x04 \x01(\v2\x15.coefficient.IncidentR\bincident\x12\x1f\n" +
+	"\vrecorded_at\x18\x05 \x01(\x03R\n" +
+	"recordedAt\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\x04R\bsequence\"V\n" +
+	"\x18StreamCoefficientRequest\x12\x1b\n" +
+	"\tevent_ids\x18\x01 \x03(\rR\beventIds\x12\x1d\n" +
+	"\n" +
+	"market_ids\x18\x02 \x03(\rR\tmarketIds\"\xbc\x03\n" +
+	"\x16CoefficientUpdateEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\rR\bmarketId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\rR\aeventId\x12'\n" +
+	"\x0fold_coefficient\x18\x04 \x01(\x01R\x0eoldCoefficient\x12'\n" +
+	"\x0fnew_coefficient\x18\x05 \x01(\x01R\x0enewCoefficient\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\bsequence\x18\a \x01(\x04R\bsequence\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x121\n" +
+	"\bincident\x18\t \x01(\v2\x15.coefficient.IncidentR\bincident\x12!\n" +
+	"\fselection_id\x18\n" +
+	" \x01(\rR\vselectionId\x12\x17\n" +
+	"\x04line\x18\v \x01(\x01H\x00R\x04line\x88\x01\x01\x12(\n" +
+	"\rprevious_line\x18\f \x01(\x01H\x01R\fpreviousLine\x88\x01\x01B\a\n" +
+	"\x05_lineB\x10\n" +
+	"\x0e_previous_line\"4\n" +
+	"\x17GetEventSnapshotRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\rR\aeventId\"\xb4\x03\n" +
+	"\x0eMarketSnapshot\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\rR\bmarketId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x123\n" +
+	"\x13current_coefficient\x18\x04 \x01(\x01B\x02\x18\x01R\x12currentCoefficient\x125\n" +
+	"\x14previous_coefficient\x18\x05 \x01(\x01B\x02\x18\x01R\x13previousCoefficient\x12%\n" +
+	"\flast_updated\x18\x06 \x01(\x03B\x02\x18\x01R\vlastUpdated\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x126\n" +
+	"\n" +
+	"selections\x18\b \x03(\v2\x16.coefficient.SelectionR\n" +
+	"selections\x12\x17\n" +
+	"\x04line\x18\t \x01(\x01H\x00R\x04line\x88\x01\x01\x12\x16\n" +
+	"\x06period\x18\n" +
+	" \x01(\tR\x06period\x12\x16\n" +
+	"\x06player\x18\v \x01(\tR\x06player\x12\x1c\n" +
+	"\ateam_id\x18\f \x01(\rH\x01R\x06teamId\x88\x01\x01B\a\n" +
+	"\x05_lineB\n" +
+	"\n" +
+	"\b_team_id\"\xf3\x01\n" +
+	"\rEventSnapshot\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\rR\aeventId\x12%\n" +
+	"\x0ecompetition_id\x18\x02 \x01(\rR\rcompetitionId\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\x125\n" +
+	"\amarkets\x18\x04 \x03(\v2\x1b.coefficient.MarketSnapshotR\amarkets\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x17\n" +
+	"\ais_live\x18\a \x01(\bR\x06isLive2\xf0\a\n" +
 	"\x12CoefficientService\x12b\n" +
-	"\x11UpdateCoefficient\x12%.coefficient.UpdateCoefficientRequest\x1a&.coefficient.UpdateCoefficientResponse\x12k\n" +
-	"\x14GetMarketCoefficient\x12(.coefficient.GetMarketCoefficientRequest\x1a).coefficient.GetMarketCoefficientResponse\x12h\n" +
-	"\x18StreamCoefficientUpdates\x12%.coefficient.StreamCoefficientRequest\x1a#.coefficient.CoefficientUpdateEvent0\x01B%Z#github.com/VaheMuradyan/Sport/protob\x06proto3"
+	"\x11UpdateCoefficient\x12%.coefficient.UpdateCoefficientRequest\x1a&.coefficient.UpdateCoefficientResponse\x12t\n" +
+	"\x17BatchUpdateCoefficients\x12+.coefficient.BatchUpdateCoefficientsRequest\x1a,.coefficient.BatchUpdateCoefficientsResponse\x12d\n" +
+	"\x12IngestCoefficients\x12&.coefficient.IngestCoefficientsRequest\x1a\".coefficient.IngestCoefficientsAck(\x010\x01\x12k\n" +
+	"\x14GetMarketCoefficient\x12(.coefficient.GetMarketCoefficientRequest\x1a).coefficient.GetMarketCoefficientResponse\x12\\\n" +
+	"\x0fSetMarketStatus\x12#.coefficient.SetMarketStatusRequest\x1a$.coefficient.SetMarketStatusResponse\x12Y\n" +
+	"\x0eMoveMarketLine\x12\".coefficient.MoveMarketLineRequest\x1a#.coefficient.MoveMarketLineResponse\x12Y\n" +
+	"\x0eSetEventStatus\x12\".coefficient.SetEventStatusRequest\x1a#.coefficient.SetEventStatusResponse\x12Y\n" +
+	"\x0eRecordIncident\x12\".coefficient.RecordIncidentRequest\x1a#.coefficient.RecordIncidentResponse\x12h\n" +
+	"\x18StreamCoefficientUpdates\x12%.coefficient.StreamCoefficientRequest\x1a#.coefficient.CoefficientUpdateEvent0\x01\x12T\n" +
+	"\x10GetEventSnapshot\x12$.coefficient.GetEventSnapshotRequest\x1a\x1a.coefficient.EventSnapshotB%Z#github.com/VaheMuradyan/Sport/protob\x06proto3"
 
 var (
 	file_proto_coefficient_proto_rawDescOnce sync.Once
@@ -474,27 +2583,79 @@ func file_proto_coefficient_proto_rawDescGZIP() []byte {
 	return file_proto_coefficient_proto_rawDescData
 }
 
-var file_proto_coefficient_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_coefficient_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_coefficient_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_proto_coefficient_proto_goTypes = []any{
-	(*UpdateCoefficientRequest)(nil),     // 0: coefficient.UpdateCoefficientRequest
-	(*UpdateCoefficientResponse)(nil),    // 1: coefficient.UpdateCoefficientResponse
-	(*GetMarketCoefficientRequest)(nil),  // 2: coefficient.GetMarketCoefficientRequest
-	(*GetMarketCoefficientResponse)(nil), // 3: coefficient.GetMarketCoefficientResponse
-	(*StreamCoefficientRequest)(nil),     // 4: coefficient.StreamCoefficientRequest
-	(*CoefficientUpdateEvent)(nil),       // 5: coefficient.CoefficientUpdateEvent
+	(IngestCoefficientsAck_RejectReason)(0), // 0: coefficient.IngestCoefficientsAck.RejectReason
+	(*UpdateCoefficientRequest)(nil),        // 1: coefficient.UpdateCoefficientRequest
+	(*UpdateCoefficientResponse)(nil),       // 2: coefficient.UpdateCoefficientResponse
+	(*BatchUpdateCoefficientsRequest)(nil),  // 3: coefficient.BatchUpdateCoefficientsRequest
+	(*MarketCoefficientChange)(nil),         // 4: coefficient.MarketCoefficientChange
+	(*BatchUpdateCoefficientsResponse)(nil), // 5: coefficient.BatchUpdateCoefficientsResponse
+	(*IngestCoefficientsRequest)(nil),       // 6: coefficient.IngestCoefficientsRequest
+	(*IngestCoefficientsAck)(nil),           // 7: coefficient.IngestCoefficientsAck
+	(*GetMarketCoefficientRequest)(nil),     // 8: coefficient.GetMarketCoefficientRequest
+	(*GetMarketCoefficientResponse)(nil),    // 9: coefficient.GetMarketCoefficientResponse
+	(*Selection)(nil),                       // 10: coefficient.Selection
+	(*SetMarketStatusRequest)(nil),          // 11: coefficient.SetMarketStatusRequest
+	(*SetMarketStatusResponse)(nil),         // 12: coefficient.SetMarketStatusResponse
+	(*MoveMarketLineRequest)(nil),           // 13: coefficient.MoveMarketLineRequest
+	(*MoveMarketLineResponse)(nil),          // 14: coefficient.MoveMarketLineResponse
+	(*SetEventStatusRequest)(nil),           // 15: coefficient.SetEventStatusRequest
+	(*SetEventStatusResponse)(nil),          // 16: coefficient.SetEventStatusResponse
+	(*RecordIncidentRequest)(nil),           // 17: coefficient.RecordIncidentRequest
+	(*TeamScore)(nil),                       // 18: coefficient.TeamScore
+	(*Incident)(nil),                        // 19: coefficient.Incident
+	(*RecordIncidentResponse)(nil),          // 20: coefficient.RecordIncidentResponse
+	(*StreamCoefficientRequest)(nil),        // 21: coefficient.StreamCoefficientRequest
+	(*CoefficientUpdateEvent)(nil),          // 22: coefficient.CoefficientUpdateEvent
+	(*GetEventSnapshotRequest)(nil),         // 23: coefficient.GetEventSnapshotRequest
+	(*MarketSnapshot)(nil),                  // 24: coefficient.MarketSnapshot
+	(*EventSnapshot)(nil),                   // 25: coefficient.EventSnapshot
+	nil,                                     // 26: coefficient.TeamScore.PeriodsEntry
 }
 var file_proto_coefficient_proto_depIdxs = []int32{
-	0, // 0: coefficient.CoefficientService.UpdateCoefficient:input_type -> coefficient.UpdateCoefficientRequest
-	2, // 1: coefficient.CoefficientService.GetMarketCoefficient:input_type -> coefficient.GetMarketCoefficientRequest
-	4, // 2: coefficient.CoefficientService.StreamCoefficientUpdates:input_type -> coefficient.StreamCoefficientRequest
-	1, // 3: coefficient.CoefficientService.UpdateCoefficient:output_type -> coefficient.UpdateCoefficientResponse
-	3, // 4: coefficient.CoefficientService.GetMarketCoefficient:output_type -> coefficient.GetMarketCoefficientResponse
-	5, // 5: coefficient.CoefficientService.StreamCoefficientUpdates:output_type -> coefficient.CoefficientUpdateEvent
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	4,  // 0: coefficient.BatchUpdateCoefficientsRequest.changes:type_name -> coefficient.MarketCoefficientChange
+	2,  // 1: coefficient.BatchUpdateCoefficientsResponse.updates:type_name -> coefficient.UpdateCoefficientResponse
+	4,  // 2: coefficient.IngestCoefficientsRequest.changes:type_name -> coefficient.MarketCoefficientChange
+	0,  // 3: coefficient.IngestCoefficientsAck.reject_reason:type_name -> coefficient.IngestCoefficientsAck.RejectReason
+	2,  // 4: coefficient.IngestCoefficientsAck.updates:type_name -> coefficient.UpdateCoefficientResponse
+	9,  // 5: coefficient.IngestCoefficientsAck.current:type_name -> coefficient.GetMarketCoefficientResponse
+	10, // 6: coefficient.GetMarketCoefficientResponse.selections:type_name -> coefficient.Selection
+	4,  // 7: coefficient.MoveMarketLineRequest.coefficients:type_name -> coefficient.MarketCoefficientChange
+	2,  // 8: coefficient.MoveMarketLineResponse.updates:type_name -> coefficient.UpdateCoefficientResponse
+	12, // 9: coefficient.SetEventStatusResponse.markets:type_name -> coefficient.SetMarketStatusResponse
+	26, // 10: coefficient.TeamScore.periods:type_name -> coefficient.TeamScore.PeriodsEntry
+	18, // 11: coefficient.Incident.score:type_name -> coefficient.TeamScore
+	19, // 12: coefficient.RecordIncidentResponse.incident:type_name -> coefficient.Incident
+	19, // 13: coefficient.CoefficientUpdateEvent.incident:type_name -> coefficient.Incident
+	10, // 14: coefficient.MarketSnapshot.selections:type_name -> coefficient.Selection
+	24, // 15: coefficient.EventSnapshot.markets:type_name -> coefficient.MarketSnapshot
+	1,  // 16: coefficient.CoefficientService.UpdateCoefficient:input_type -> coefficient.UpdateCoefficientRequest
+	3,  // 17: coefficient.CoefficientService.BatchUpdateCoefficients:input_type -> coefficient.BatchUpdateCoefficientsRequest
+	6,  // 18: coefficient.CoefficientService.IngestCoefficients:input_type -> coefficient.IngestCoefficientsRequest
+	8,  // 19: coefficient.CoefficientService.GetMarketCoefficient:input_type -> coefficient.GetMarketCoefficientRequest
+	11, // 20: coefficient.CoefficientService.SetMarketStatus:input_type -> coefficient.SetMarketStatusRequest
+	13, // 21: coefficient.CoefficientService.MoveMarketLine:input_type -> coefficient.MoveMarketLineRequest
+	15, // 22: coefficient.CoefficientService.SetEventStatus:input_type -> coefficient.SetEventStatusRequest
+	17, // 23: coefficient.CoefficientService.RecordIncident:input_type -> coefficient.RecordIncidentRequest
+	21, // 24: coefficient.CoefficientService.StreamCoefficientUpdates:input_type -> coefficient.StreamCoefficientRequest
+	23, // 25: coefficient.CoefficientService.GetEventSnapshot:input_type -> coefficient.GetEventSnapshotRequest
+	2,  // 26: coefficient.CoefficientService.UpdateCoefficient:output_type -> coefficient.UpdateCoefficientResponse
+	5,  // 27: coefficient.CoefficientService.BatchUpdateCoefficients:output_type -> coefficient.BatchUpdateCoefficientsResponse
+	7,  // 28: coefficient.CoefficientService.IngestCoefficients:output_type -> coefficient.IngestCoefficientsAck
+	9,  // 29: coefficient.CoefficientService.GetMarketCoefficient:output_type -> coefficient.GetMarketCoefficientResponse
+	12, // 30: coefficient.CoefficientService.SetMarketStatus:output_type -> coefficient.SetMarketStatusResponse
+	14, // 31: coefficient.CoefficientService.MoveMarketLine:output_type -> coefficient.MoveMarketLineResponse
+	16, // 32: coefficient.CoefficientService.SetEventStatus:output_type -> coefficient.SetEventStatusResponse
+	20, // 33: coefficient.CoefficientService.RecordIncident:output_type -> coefficient.RecordIncidentResponse
+	22, // 34: coefficient.CoefficientService.StreamCoefficientUpdates:output_type -> coefficient.CoefficientUpdateEvent
+	25, // 35: coefficient.CoefficientService.GetEventSnapshot:output_type -> coefficient.EventSnapshot
+	26, // [26:36] is the sub-list for method output_type
+	16, // [16:26] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_proto_coefficient_proto_init() }
@@ -502,18 +2663,29 @@ func file_proto_coefficient_proto_init() {
 	if File_proto_coefficient_proto != nil {
 		return
 	}
+	file_proto_coefficient_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_coefficient_proto_msgTypes[1].OneofWrappers = []any{}
+	file_proto_coefficient_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_coefficient_proto_msgTypes[8].OneofWrappers = []any{}
+	file_proto_coefficient_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_coefficient_proto_msgTypes[12].OneofWrappers = []any{}
+	file_proto_coefficient_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_coefficient_proto_msgTypes[16].OneofWrappers = []any{}
+	file_proto_coefficient_proto_msgTypes[21].OneofWrappers = []any{}
+	file_proto_coefficient_proto_msgTypes[23].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_coefficient_proto_rawDesc), len(file_proto_coefficient_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_coefficient_proto_goTypes,
 		DependencyIndexes: file_proto_coefficient_proto_depIdxs,
+		EnumInfos:         file_proto_coefficient_proto_enumTypes,
 		MessageInfos:      file_proto_coefficient_proto_msgTypes,
 	}.Build()
 	File_proto_coefficient_proto = out.File
@@ -20,8 +20,15 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	CoefficientService_UpdateCoefficient_FullMethodName        = "/coefficient.CoefficientService/UpdateCoefficient"
+	CoefficientService_BatchUpdateCoefficients_FullMethodName  = "/coefficient.CoefficientService/BatchUpdateCoefficients"
+	CoefficientService_IngestCoefficients_FullMethodName       = "/coefficient.CoefficientService/IngestCoefficients"
 	CoefficientService_GetMarketCoefficient_FullMethodName     = "/coefficient.CoefficientService/GetMarketCoefficient"
+	CoefficientService_SetMarketStatus_FullMethodName          = "/coefficient.CoefficientService/SetMarketStatus"
+	CoefficientService_MoveMarketLine_FullMethodName           = "/coefficient.CoefficientService/MoveMarketLine"
+	CoefficientService_SetEventStatus_FullMethodName           = "/coefficient.CoefficientService/SetEventStatus"
+	CoefficientService_RecordIncident_FullMethodName           = "/coefficient.CoefficientService/RecordIncident"
 	CoefficientService_StreamCoefficientUpdates_FullMethodName = "/coefficient.CoefficientService/StreamCoefficientUpdates"
+	CoefficientService_GetEventSnapshot_FullMethodName         = "/coefficient.CoefficientService/GetEventSnapshot"
 )
 
 // CoefficientServiceClient is the client API for CoefficientService service.
@@ -29,8 +36,15 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CoefficientServiceClient interface {
 	UpdateCoefficient(ctx context.Context, in *UpdateCoefficientRequest, opts ...grpc.CallOption) (*UpdateCoefficientResponse, error)
+	BatchUpdateCoefficients(ctx context.Context, in *BatchUpdateCoefficientsRequest, opts ...grpc.CallOption) (*BatchUpdateCoefficientsResponse, error)
+	IngestCoefficients(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[IngestCoefficientsRequest, IngestCoefficientsAck], error)
 	GetMarketCoefficient(ctx context.Context, in *GetMarketCoefficientRequest, opts ...grpc.CallOption) (*GetMarketCoefficientResponse, error)
+	SetMarketStatus(ctx context.Context, in *SetMarketStatusRequest, opts ...grpc.CallOption) (*SetMarketStatusResponse, error)
+	MoveMarketLine(ctx context.Context, in *MoveMarketLineRequest, opts ...grpc.CallOption) (*MoveMarketLineResponse, error)
+	SetEventStatus(ctx context.Context, in *SetEventStatusRequest, opts ...grpc.CallOption) (*SetEventStatusResponse, error)
+	RecordIncident(ctx context.Context, in *RecordIncidentRequest, opts ...grpc.CallOption) (*RecordIncidentResponse, error)
 	StreamCoefficientUpdates(ctx context.Context, in *StreamCoefficientRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CoefficientUpdateEvent], error)
+	GetEventSnapshot(ctx context.Context, in *GetEventSnapshotRequest, opts ...grpc.CallOption) (*EventSnapshot, error)
 }
 
 type coefficientServiceClient struct {
@@ -51,6 +65,29 @@ func (c *coefficientServiceClient) UpdateCoefficient(ctx context.Context, in *Up
 	return out, nil
 }
 
+func (c *coefficientServiceClient) BatchUpdateCoefficients(ctx context.Context, in *BatchUpdateCoefficientsRequest, opts ...grpc.CallOption) (*BatchUpdateCoefficientsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdateCoefficientsResponse)
+	err := c.cc.Invoke(ctx, CoefficientService_BatchUpdateCoefficients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coefficientServiceClient) IngestCoefficients(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[IngestCoefficientsRequest, IngestCoefficientsAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CoefficientService_ServiceDesc.Streams[0], CoefficientService_IngestCoefficients_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[IngestCoefficientsRequest, IngestCoefficientsAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CoefficientService_IngestCoefficientsClient = grpc.BidiStreamingClient[IngestCoefficientsRequest, IngestCoefficientsAck]
+
 func (c *coefficientServiceClient) GetMarketCoefficient(ctx context.Context, in *GetMarketCoefficientRequest, opts ...grpc.CallOption) (*GetMarketCoefficientResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetMarketCoefficientResponse)
@@ -61,9 +98,49 @@ func (c *coefficientServiceClient) GetMarketCoefficient(ctx context.Context, in
 	return out, nil
 }
 
+func (c *coefficientServiceClient) SetMarketStatus(ctx context.Context, in *SetMarketStatusRequest, opts ...grpc.CallOption) (*SetMarketStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMarketStatusResponse)
+	err := c.cc.Invoke(ctx, CoefficientService_SetMarketStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coefficientServiceClient) MoveMarketLine(ctx context.Context, in *MoveMarketLineRequest, opts ...grpc.CallOption) (*MoveMarketLineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveMarketLineResponse)
+	err := c.cc.Invoke(ctx, CoefficientService_MoveMarketLine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coefficientServiceClient) SetEventStatus(ctx context.Context, in *SetEventStatusRequest, opts ...grpc.CallOption) (*SetEventStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetEventStatusResponse)
+	err := c.cc.Invoke(ctx, CoefficientService_SetEventStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coefficientServiceClient) RecordIncident(ctx context.Context, in *RecordIncidentRequest, opts ...grpc.CallOption) (*RecordIncidentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordIncidentResponse)
+	err := c.cc.Invoke(ctx, CoefficientService_RecordIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *coefficientServiceClient) StreamCoefficientUpdates(ctx context.Context, in *StreamCoefficientRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CoefficientUpdateEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &CoefficientService_ServiceDesc.Streams[0], CoefficientService_StreamCoefficientUpdates_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &CoefficientService_ServiceDesc.Streams[1], CoefficientService_StreamCoefficientUpdates_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -80,13 +157,30 @@ func (c *coefficientServiceClient) StreamCoefficientUpdates(ctx context.Context,
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type CoefficientService_StreamCoefficientUpdatesClient = grpc.ServerStreamingClient[CoefficientUpdateEvent]
 
+func (c *coefficientServiceClient) GetEventSnapshot(ctx context.Context, in *GetEventSnapshotRequest, opts ...grpc.CallOption) (*EventSnapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventSnapshot)
+	err := c.cc.Invoke(ctx, CoefficientService_GetEventSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CoefficientServiceServer is the server API for CoefficientService service.
 // All implementations must embed UnimplementedCoefficientServiceServer
 // for forward compatibility.
 type CoefficientServiceServer interface {
 	UpdateCoefficient(context.Context, *UpdateCoefficientRequest) (*UpdateCoefficientResponse, error)
+	BatchUpdateCoefficients(context.Context, *BatchUpdateCoefficientsRequest) (*BatchUpdateCoefficientsResponse, error)
+	IngestCoefficients(grpc.BidiStreamingServer[IngestCoefficientsRequest, IngestCoefficientsAck]) error
 	GetMarketCoefficient(context.Context, *GetMarketCoefficientRequest) (*GetMarketCoefficientResponse, error)
+	SetMarketStatus(context.Context, *SetMarketStatusRequest) (*SetMarketStatusResponse, error)
+	MoveMarketLine(context.Context, *MoveMarketLineRequest) (*MoveMarketLineResponse, error)
+	SetEventStatus(context.Context, *SetEventStatusRequest) (*SetEventStatusResponse, error)
+	RecordIncident(context.Context, *RecordIncidentRequest) (*RecordIncidentResponse, error)
 	StreamCoefficientUpdates(*StreamCoefficientRequest, grpc.ServerStreamingServer[CoefficientUpdateEvent]) error
+	GetEventSnapshot(context.Context, *GetEventSnapshotRequest) (*EventSnapshot, error)
 	mustEmbedUnimplementedCoefficientServiceServer()
 }
 
@@ -100,12 +194,33 @@ type UnimplementedCoefficientServiceServer struct{}
 func (UnimplementedCoefficientServiceServer) UpdateCoefficient(context.Context, *UpdateCoefficientRequest) (*UpdateCoefficientResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCoefficient not implemented")
 }
+func (UnimplementedCoefficientServiceServer) BatchUpdateCoefficients(context.Context, *BatchUpdateCoefficientsRequest) (*BatchUpdateCoefficientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateCoefficients not implemented")
+}
+func (UnimplementedCoefficientServiceServer) IngestCoefficients(grpc.BidiStreamingServer[IngestCoefficientsRequest, IngestCoefficientsAck]) error {
+	return status.Errorf(codes.Unimplemented, "method IngestCoefficients not implemented")
+}
 func (UnimplementedCoefficientServiceServer) GetMarketCoefficient(context.Context, *GetMarketCoefficientRequest) (*GetMarketCoefficientResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMarketCoefficient not implemented")
 }
+func (UnimplementedCoefficientServiceServer) SetMarketStatus(context.Context, *SetMarketStatusRequest) (*SetMarketStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMarketStatus not implemented")
+}
+func (UnimplementedCoefficientServiceServer) MoveMarketLine(context.Context, *MoveMarketLineRequest) (*MoveMarketLineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveMarketLine not implemented")
+}
+func (UnimplementedCoefficientServiceServer) SetEventStatus(context.Context, *SetEventStatusRequest) (*SetEventStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetEventStatus not implemented")
+}
+func (UnimplementedCoefficientServiceServer) RecordIncident(context.Context, *RecordIncidentRequest) (*RecordIncidentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordIncident not implemented")
+}
 func (UnimplementedCoefficientServiceServer) StreamCoefficientUpdates(*StreamCoefficientRequest, grpc.ServerStreamingServer[CoefficientUpdateEvent]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamCoefficientUpdates not implemented")
 }
+func (UnimplementedCoefficientServiceServer) GetEventSnapshot(context.Context, *GetEventSnapshotRequest) (*EventSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventSnapshot not implemented")
+}
 func (UnimplementedCoefficientServiceServer) mustEmbedUnimplementedCoefficientServiceServer() {}
 func (UnimplementedCoefficientServiceServer) testEmbeddedByValue()                            {}
 
@@ -145,6 +260,31 @@ func _CoefficientService_UpdateCoefficient_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CoefficientService_BatchUpdateCoefficients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateCoefficientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoefficientServiceServer).BatchUpdateCoefficients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoefficientService_BatchUpdateCoefficients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoefficientServiceServer).BatchUpdateCoefficients(ctx, req.(*BatchUpdateCoefficientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoefficientService_IngestCoefficients_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CoefficientServiceServer).IngestCoefficients(&grpc.GenericServerStream[IngestCoefficientsRequest, IngestCoefficientsAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CoefficientService_IngestCoefficientsServer = grpc.BidiStreamingServer[IngestCoefficientsRequest, IngestCoefficientsAck]
+
 func _CoefficientService_GetMarketCoefficient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMarketCoefficientRequest)
 	if err := dec(in); err != nil {
@@ -163,6 +303,78 @@ func _CoefficientService_GetMarketCoefficient_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CoefficientService_SetMarketStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMarketStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoefficientServiceServer).SetMarketStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoefficientService_SetMarketStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoefficientServiceServer).SetMarketStatus(ctx, req.(*SetMarketStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoefficientService_MoveMarketLine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveMarketLineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoefficientServiceServer).MoveMarketLine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoefficientService_MoveMarketLine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoefficientServiceServer).MoveMarketLine(ctx, req.(*MoveMarketLineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoefficientService_SetEventStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEventStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoefficientServiceServer).SetEventStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoefficientService_SetEventStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoefficientServiceServer).SetEventStatus(ctx, req.(*SetEventStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoefficientService_RecordIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoefficientServiceServer).RecordIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoefficientService_RecordIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoefficientServiceServer).RecordIncident(ctx, req.(*RecordIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CoefficientService_StreamCoefficientUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StreamCoefficientRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -174,6 +386,24 @@ func _CoefficientService_StreamCoefficientUpdates_Handler(srv interface{}, strea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type CoefficientService_StreamCoefficientUpdatesServer = grpc.ServerStreamingServer[CoefficientUpdateEvent]
 
+func _CoefficientService_GetEventSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoefficientServiceServer).GetEventSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoefficientService_GetEventSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoefficientServiceServer).GetEventSnapshot(ctx, req.(*GetEventSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CoefficientService_ServiceDesc is the grpc.ServiceDesc for CoefficientService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -185,12 +415,42 @@ var CoefficientService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateCoefficient",
 			Handler:    _CoefficientService_UpdateCoefficient_Handler,
 		},
+		{
+			MethodName: "BatchUpdateCoefficients",
+			Handler:    _CoefficientService_BatchUpdateCoefficients_Handler,
+		},
 		{
 			MethodName: "GetMarketCoefficient",
 			Handler:    _CoefficientService_GetMarketCoefficient_Handler,
 		},
+		{
+			MethodName: "SetMarketStatus",
+			Handler:    _CoefficientService_SetMarketStatus_Handler,
+		},
+		{
+			MethodName: "MoveMarketLine",
+			Handler:    _CoefficientService_MoveMarketLine_Handler,
+		},
+		{
+			MethodName: "SetEventStatus",
+			Handler:    _CoefficientService_SetEventStatus_Handler,
+		},
+		{
+			MethodName: "RecordIncident",
+			Handler:    _CoefficientService_RecordIncident_Handler,
+		},
+		{
+			MethodName: "GetEventSnapshot",
+			Handler:    _CoefficientService_GetEventSnapshot_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "IngestCoefficients",
+			Handler:       _CoefficientService_IngestCoefficients_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "StreamCoefficientUpdates",
 			Handler:       _CoefficientService_StreamCoefficientUpdates_Handler,
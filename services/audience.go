@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/config"
+	"github.com/VaheMuradyan/Sport/models"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+var (
+	ErrEventNotFound       = errors.New("event not found")
+	ErrPresenceUnavailable = errors.New("presence unavailable")
+)
+
+// ChannelAudience is the presence of one channel.
+type ChannelAudience struct {
+	Channel    string `json:"channel"`
+	NumClients uint32 `json:"num_clients"`
+	NumUsers   uint32 `json:"num_users"`
+}
+
+type MarketAudience struct {
+	MarketID uint   `json:"market_id"`
+	Name     string `json:"name"`
+	ChannelAudience
+}
+
+// EventAudience is the number of clients and users watching an event and each
+// of its markets.
+type EventAudience struct {
+	EventID   uint             `json:"event_id"`
+	Event     ChannelAudience  `json:"event"`
+	Markets   []MarketAudience `json:"markets"`
+	SampledAt time.Time        `json:"sampled_at"`
+}
+
+// AudienceService reads the audience of event and market channels from
+// Centrifugo presence stats and records it periodically.
+type AudienceService struct {
+	db         *gorm.DB
+	centrifugo CentrifugoAPI
+	cfg        config.AudienceConfig
+}
+
+func NewAudienceService(db *gorm.DB, centrifugo CentrifugoAPI, cfg config.AudienceConfig) *AudienceService {
+	return &AudienceService{
+		db:         db,
+		centrifugo: centrifugo,
+		cfg:        cfg,
+	}
+}
+
+// EventAudience returns the current audience of the event and its markets.
+func (s *AudienceService) EventAudience(ctx context.Context, eventID uint) (*EventAudience, error) {
+	var event models.Event
+	if err := s.db.Select("id").First(&event, eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to load event: %v", err)
+	}
+
+	audiences, err := s.sample(ctx, []models.Event{event})
+	if err != nil {
+		return nil, err
+	}
+	return &audiences[0], nil
+}
+
+// Samples returns the recorded audience of the event since the given time,
+// oldest first.
+func (s *AudienceService) Samples(eventID uint, since time.Time) ([]models.AudienceSample, error) {
+	var samples []models.AudienceSample
+	err := s.db.
+		Where("event_id = ? AND sampled_at >= ?", eventID, since).
+		Order("sampled_at, id").
+		Find(&samples).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audience samples: %v", err)
+	}
+	return samples, nil
+}
+
+// Run records the audience of live and upcoming events every interval until
+// ctx is cancelled.
+func (s *AudienceService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	lastPurge := time.Time{}
+	for {
+		s.record(ctx)
+
+		if time.Since(lastPurge) >= time.Hour {
+			s.purgeSamples()
+			lastPurge = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *AudienceService) record(ctx context.Context) {
+	var events []models.Event
+	err := s.db.Select("id").
+		Where("is_live = ? OR status = ? OR (start_time BETWEEN ? AND ?)", true, "live", time.Now(), time.Now().Add(s.cfg.Lookahead)).
+		Find(&events).Error
+	if err != nil {
+		log.Printf("❌ Failed to load events for audience sampling: %v", err)
+		return
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Interval)
+	defer cancel()
+
+	audiences, err := s.sample(ctx, events)
+	if err != nil {
+		log.Printf("❌ Failed to sample audience: %v", err)
+		return
+	}
+
+	var samples []models.AudienceSample
+	for _, audience := range audiences {
+		samples = append(samples, models.AudienceSample{
+			EventID:    audience.EventID,
+			Channel:    audience.Event.Channel,
+			NumClients: audience.Event.NumClients,
+			NumUsers:   audience.Event.NumUsers,
+			SampledAt:  audience.SampledAt,
+		})
+		for _, market := range audience.Markets {
+			marketID := market.MarketID
+			samples = append(samples, models.AudienceSample{
+				EventID:    audience.EventID,
+				MarketID:   &marketID,
+				Channel:    market.Channel,
+				NumClients: market.NumClients,
+				NumUsers:   market.NumUsers,
+				SampledAt:  audience.SampledAt,
+			})
+		}
+	}
+
+	if err := s.db.CreateInBatches(samples, 500).Error; err != nil {
+		log.Printf("❌ Failed to store audience samples: %v", err)
+		return
+	}
+	log.Printf("👀 Sampled audience of %d events", len(audiences))
+}
+
+// sample reads the presence stats of the events and their markets.
+func (s *AudienceService) sample(ctx context.Context, events []models.Event) ([]EventAudience, error) {
+	eventIDs := make([]uint, len(events))
+	for i, event := range events {
+		eventIDs[i] = event.ID
+	}
+
+	var markets []models.Market
+	if err := s.db.Select("id", "name", "event_id").Where("event_id IN ?", eventIDs).Order("id").Find(&markets).Error; err != nil {
+		return nil, fmt.Errorf("failed to load markets: %v", err)
+	}
+	marketsByEvent := make(map[uint][]models.Market)
+	for _, market := range markets {
+		marketsByEvent[market.EventID] = append(marketsByEvent[market.EventID], market)
+	}
+
+	audiences := make([]EventAudience, len(events))
+	for i, event := range events {
+		eventAudience, err := s.channelAudience(ctx, EventChannel(event.ID))
+		if err != nil {
+			return nil, err
+		}
+
+		audiences[i] = EventAudience{
+			EventID:   event.ID,
+			Event:     eventAudience,
+			Markets:   []MarketAudience{},
+			SampledAt: time.Now(),
+		}
+		for _, market := range marketsByEvent[event.ID] {
+			marketAudience, err := s.channelAudience(ctx, MarketChannel(market.ID))
+			if err != nil {
+				return nil, err
+			}
+			audiences[i].Markets = append(audiences[i].Markets, MarketAudience{
+				MarketID:        market.ID,
+				Name:            market.Name,
+				ChannelAudience: marketAudience,
+			})
+		}
+	}
+	return audiences, nil
+}
+
+func (s *AudienceService) channelAudience(ctx context.Context, channel string) (ChannelAudience, error) {
+	stats, err := s.centrifugo.PresenceStats(ctx, channel)
+	if err != nil {
+		return ChannelAudience{}, fmt.Errorf("%w: %s: %v", ErrPresenceUnavailable, channel, err)
+	}
+	return ChannelAudience{
+		Channel:    channel,
+		NumClients: stats.NumClients,
+		NumUsers:   stats.NumUsers,
+	}, nil
+}
+
+func (s *AudienceService) purgeSamples() {
+	cutoff := time.Now().Add(-s.cfg.Retention)
+	err := s.db.Unscoped().
+		Where("sampled_at < ?", cutoff).
+		Delete(&models.AudienceSample{}).Error
+	if err != nil {
+		log.Printf("❌ Failed to purge audience samples: %v", err)
+	}
+}
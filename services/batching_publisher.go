@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"log"
+	"sync"
+	"time"
+)
+
+var errPublisherClosed = errors.New("publisher closed")
+
+var (
+	centrifugoBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "sport_centrifugo_batch_size",
+		Help:    "Number of publications sent in one Centrifugo batch request.",
+		Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500},
+	})
+	centrifugoBatchLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "sport_centrifugo_batch_duration_seconds",
+		Help:    "Duration of Centrifugo batch requests.",
+		Buckets: prometheus.DefBuckets,
+	})
+	centrifugoBatchPublications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sport_centrifugo_batch_publications_total",
+		Help: "Publications sent in Centrifugo batch requests by result.",
+	}, []string{"result"})
+)
+
+type batchItem struct {
+	publication Publication
+	result      chan error
+}
+
+// BatchingPublisher publishes to Centrifugo through its batch API.
+// Publications, single ones and those of PublishBatch alike, are collected for
+// up to window or maxSize items and sent in one request. Every publication
+// gets its own result.
+type BatchingPublisher struct {
+	centrifugo CentrifugoAPI
+	window     time.Duration
+	maxSize    int
+	items      chan *batchItem
+	done       chan struct{}
+	mu         sync.RWMutex // guards closed and sends on items
+	closed     bool
+	wg         sync.WaitGroup
+}
+
+func NewBatchingPublisher(centrifugo CentrifugoAPI, window time.Duration, maxSize int) *BatchingPublisher {
+	if maxSize <= 0 {
+		maxSize = 1
+	}
+	p := &BatchingPublisher{
+		centrifugo: centrifugo,
+		window:     window,
+		maxSize:    maxSize,
+		items:      make(chan *batchItem, maxSize),
+		done:       make(chan struct{}),
+	}
+
+	p.wg.Add(1)
+	go p.run()
+	return p
+}
+
+// Publish queues the publication for the next batch and waits for its
+// result.
+func (p *BatchingPublisher) Publish(ctx context.Context, channels []string, payload []byte) error {
+	return p.PublishBatch(ctx, []Publication{{Channels: channels, Payload: payload}})[0]
+}
+
+// PublishBatch queues the publications for the next batches and waits for
+// their results. They may share a batch request with publications queued
+// concurrently.
+func (p *BatchingPublisher) PublishBatch(ctx context.Context, publications []Publication) []error {
+	errs := make([]error, len(publications))
+	items := make([]*batchItem, 0, len(publications))
+
+	p.mu.RLock()
+	for i, publication := range publications {
+		if p.closed {
+			errs[i] = errPublisherClosed
+			continue
+		}
+		item := &batchItem{publication: publication, result: make(chan error, 1)}
+		select {
+		case p.items <- item:
+			items = append(items, item)
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		}
+	}
+	p.mu.RUnlock()
+
+	queued := 0
+	for i := range publications {
+		if errs[i] != nil {
+			continue
+		}
+		select {
+		case errs[i] = <-items[queued].result:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		}
+		queued++
+	}
+	return errs
+}
+
+// Close sends the publications collected so far and stops the publisher.
+func (p *BatchingPublisher) Close() error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	return nil
+}
+
+func (p *BatchingPublisher) run() {
+	defer p.wg.Done()
+
+	for {
+		var first *batchItem
+		select {
+		case first = <-p.items:
+		case <-p.done:
+			p.rejectQueued()
+			return
+		}
+
+		batch := []*batchItem{first}
+		timer := time.NewTimer(p.window)
+	collect:
+		for len(batch) < p.maxSize {
+			select {
+			case item := <-p.items:
+				batch = append(batch, item)
+			case <-timer.C:
+				break collect
+			case <-p.done:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		p.flush(batch)
+	}
+}
+
+// rejectQueued fails the publications queued after Close.
+func (p *BatchingPublisher) rejectQueued() {
+	for {
+		select {
+		case item := <-p.items:
+			item.result <- errPublisherClosed
+		default:
+			return
+		}
+	}
+}
+
+func (p *BatchingPublisher) flush(batch []*batchItem) {
+	publications := make([]Publication, len(batch))
+	for i, item := range batch {
+		publications[i] = item.publication
+	}
+
+	errs := p.send(context.Background(), publications)
+	for i, item := range batch {
+		item.result <- errs[i]
+	}
+}
+
+// send delivers one batch request and records its metrics.
+func (p *BatchingPublisher) send(ctx context.Context, publications []Publication) []error {
+	start := time.Now()
+	errs, err := p.centrifugo.broadcastBatch(ctx, publications)
+	centrifugoBatchLatency.Observe(time.Since(start).Seconds())
+	centrifugoBatchSize.Observe(float64(len(publications)))
+
+	if err != nil {
+		log.Printf("❌ Failed to send Centrifugo batch of %d publications: %v", len(publications), err)
+		errs = make([]error, len(publications))
+		for i := range errs {
+			errs[i] = err
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			centrifugoBatchPublications.WithLabelValues("error").Inc()
+		} else {
+			centrifugoBatchPublications.WithLabelValues("ok").Inc()
+		}
+	}
+	return errs
+}
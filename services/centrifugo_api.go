@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/config"
+)
+
+// CentrifugoAPI is a typed client for the Centrifugo server API. It is
+// implemented over HTTP by CentrifugoService and over gRPC by
+// CentrifugoGRPCClient. Both are also Publishers that broadcast every
+// publication in a single call.
+type CentrifugoAPI interface {
+	Publisher
+
+	PublishChannel(ctx context.Context, channel string, data []byte) (*PublishResult, error)
+	Broadcast(ctx context.Context, channels []string, data []byte) ([]BroadcastResponse, error)
+	Presence(ctx context.Context, channel string) (map[string]ClientInfo, error)
+	PresenceStats(ctx context.Context, channel string) (*PresenceStats, error)
+	History(ctx context.Context, channel string, opts HistoryOptions) (*HistoryResult, error)
+	HistoryRemove(ctx context.Context, channel string) error
+	Unsubscribe(ctx context.Context, channel, user string) error
+	Disconnect(ctx context.Context, user string) error
+	Info(ctx context.Context) ([]NodeInfo, error)
+	Channels(ctx context.Context, pattern string) (map[string]ChannelInfo, error)
+
+	// broadcastBatch sends one broadcast per publication in a single
+	// request. See BatchingPublisher.
+	broadcastBatch(ctx context.Context, publications []Publication) ([]error, error)
+}
+
+// NewCentrifugoAPI creates the client for the configured API transport.
+func NewCentrifugoAPI(cfg config.CentrifugoConfig) (CentrifugoAPI, error) {
+	switch cfg.APITransport {
+	case config.TransportHTTP:
+		return NewCentrifugoService(cfg.APIURL, cfg.APIKey, cfg.APIVersion), nil
+	case config.TransportGRPC:
+		client, err := NewCentrifugoGRPCClient(cfg.GRPCAddr, cfg.APIKey, cfg.GRPCTLS)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unknown Centrifugo API transport %q", cfg.APITransport)
+	}
+}
+
+// CentrifugoError is an error returned by the Centrifugo API.
+type CentrifugoError struct {
+	Code    uint32 `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *CentrifugoError) Error() string {
+	return fmt.Sprintf("centrifugo error %d: %s", e.Code, e.Message)
+}
+
+// StreamPosition is a position in a channel's history stream.
+type StreamPosition struct {
+	Offset uint64 `json:"offset"`
+	Epoch  string `json:"epoch"`
+}
+
+type PublishResult struct {
+	StreamPosition
+}
+
+// BroadcastResponse is the outcome of a broadcast for one channel.
+type BroadcastResponse struct {
+	Channel string
+	Result  PublishResult
+	Err     error
+}
+
+type ClientInfo struct {
+	User     string          `json:"user"`
+	Client   string          `json:"client"`
+	ConnInfo json.RawMessage `json:"conn_info,omitempty"`
+	ChanInfo json.RawMessage `json:"chan_info,omitempty"`
+}
+
+type PresenceStats struct {
+	NumClients uint32 `json:"num_clients"`
+	NumUsers   uint32 `json:"num_users"`
+}
+
+// HistoryOptions selects the publications History returns. A nil Since
+// with Limit 0 only returns the current stream position.
+type HistoryOptions struct {
+	Limit   int32           `json:"limit,omitempty"`
+	Since   *StreamPosition `json:"since,omitempty"`
+	Reverse bool            `json:"reverse,omitempty"`
+}
+
+type HistoryPublication struct {
+	Offset uint64            `json:"offset"`
+	Data   json.RawMessage   `json:"data"`
+	Info   *ClientInfo       `json:"info,omitempty"`
+	Tags   map[string]string `json:"tags,omitempty"`
+}
+
+type HistoryResult struct {
+	Publications []HistoryPublication `json:"publications"`
+	Epoch        string               `json:"epoch"`
+	Offset       uint64               `json:"offset"`
+}
+
+type NodeInfo struct {
+	UID         string `json:"uid"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	NumClients  uint32 `json:"num_clients"`
+	NumUsers    uint32 `json:"num_users"`
+	NumChannels uint32 `json:"num_channels"`
+	NumSubs     uint32 `json:"num_subs"`
+	Uptime      uint32 `json:"uptime"`
+}
+
+type ChannelInfo struct {
+	NumClients uint32 `json:"num_clients"`
+}
+
+// publishBroadcast implements Publisher.Publish on top of Broadcast. It
+// fails if the publication failed on any channel.
+func publishBroadcast(ctx context.Context, api CentrifugoAPI, channels []string, payload []byte) error {
+	responses, err := api.Broadcast(ctx, channels, payload)
+	if err != nil {
+		return err
+	}
+	return firstBroadcastError(responses)
+}
+
+func firstBroadcastError(responses []BroadcastResponse) error {
+	for _, response := range responses {
+		if response.Err != nil {
+			return fmt.Errorf("%v on channel %s", response.Err, response.Channel)
+		}
+	}
+	return nil
+}
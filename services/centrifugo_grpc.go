@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	centrifugoapi "github.com/VaheMuradyan/Sport/proto/centrifugo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// CentrifugoGRPCClient is the Centrifugo API client for the gRPC transport.
+type CentrifugoGRPCClient struct {
+	conn   *grpc.ClientConn
+	client centrifugoapi.CentrifugoApiClient
+}
+
+// apiKeyCredentials sends the API key the way Centrifugo's grpc_api_key
+// option expects it.
+type apiKeyCredentials struct {
+	key    string
+	secure bool
+}
+
+func (c apiKeyCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "apikey " + c.key}, nil
+}
+
+func (c apiKeyCredentials) RequireTransportSecurity() bool {
+	return c.secure
+}
+
+func NewCentrifugoGRPCClient(addr, apiKey string, useTLS bool) (*CentrifugoGRPCClient, error) {
+	transportCredentials := insecure.NewCredentials()
+	if useTLS {
+		transportCredentials = credentials.NewTLS(&tls.Config{})
+	}
+
+	options := []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials)}
+	if apiKey != "" {
+		options = append(options, grpc.WithPerRPCCredentials(apiKeyCredentials{key: apiKey, secure: useTLS}))
+	}
+
+	conn, err := grpc.NewClient(addr, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Centrifugo gRPC client: %v", err)
+	}
+
+	return &CentrifugoGRPCClient{
+		conn:   conn,
+		client: centrifugoapi.NewCentrifugoApiClient(conn),
+	}, nil
+}
+
+// Publish broadcasts the payload to the channels in a single API call.
+func (c *CentrifugoGRPCClient) Publish(ctx context.Context, channels []string, payload []byte) error {
+	return publishBroadcast(ctx, c, channels, payload)
+}
+
+func (c *CentrifugoGRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *CentrifugoGRPCClient) PublishChannel(ctx context.Context, channel string, data []byte) (*PublishResult, error) {
+	resp, err := c.client.Publish(ctx, &centrifugoapi.PublishRequest{Channel: channel, Data: data})
+	if err != nil {
+		return nil, err
+	}
+	if err := apiError(resp.GetError()); err != nil {
+		return nil, err
+	}
+	return publishResult(resp.GetResult()), nil
+}
+
+func (c *CentrifugoGRPCClient) Broadcast(ctx context.Context, channels []string, data []byte) ([]BroadcastResponse, error) {
+	resp, err := c.client.Broadcast(ctx, &centrifugoapi.BroadcastRequest{Channels: channels, Data: data})
+	if err != nil {
+		return nil, err
+	}
+	if err := apiError(resp.GetError()); err != nil {
+		return nil, err
+	}
+	return grpcBroadcastResponses(channels, resp.GetResult()), nil
+}
+
+func (c *CentrifugoGRPCClient) Presence(ctx context.Context, channel string) (map[string]ClientInfo, error) {
+	resp, err := c.client.Presence(ctx, &centrifugoapi.PresenceRequest{Channel: channel})
+	if err != nil {
+		return nil, err
+	}
+	if err := apiError(resp.GetError()); err != nil {
+		return nil, err
+	}
+
+	presence := make(map[string]ClientInfo, len(resp.GetResult().GetPresence()))
+	for id, info := range resp.GetResult().GetPresence() {
+		presence[id] = *clientInfo(info)
+	}
+	return presence, nil
+}
+
+func (c *CentrifugoGRPCClient) PresenceStats(ctx context.Context, channel string) (*PresenceStats, error) {
+	resp, err := c.client.PresenceStats(ctx, &centrifugoapi.PresenceStatsRequest{Channel: channel})
+	if err != nil {
+		return nil, err
+	}
+	if err := apiError(resp.GetError()); err != nil {
+		return nil, err
+	}
+	return &PresenceStats{
+		NumClients: resp.GetResult().GetNumClients(),
+		NumUsers:   resp.GetResult().GetNumUsers(),
+	}, nil
+}
+
+func (c *CentrifugoGRPCClient) History(ctx context.Context, channel string, opts HistoryOptions) (*HistoryResult, error) {
+	req := &centrifugoapi.HistoryRequest{
+		Channel: channel,
+		Limit:   opts.Limit,
+		Reverse: opts.Reverse,
+	}
+	if opts.Since != nil {
+		req.Since = &centrifugoapi.StreamPosition{Offset: opts.Since.Offset, Epoch: opts.Since.Epoch}
+	}
+
+	resp, err := c.client.History(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := apiError(resp.GetError()); err != nil {
+		return nil, err
+	}
+
+	result := &HistoryResult{
+		Epoch:  resp.GetResult().GetEpoch(),
+		Offset: resp.GetResult().GetOffset(),
+	}
+	for _, publication := range resp.GetResult().GetPublications() {
+		result.Publications = append(result.Publications, HistoryPublication{
+			Offset: publication.GetOffset(),
+			Data:   json.RawMessage(publication.GetData()),
+			Info:   clientInfo(publication.GetInfo()),
+			Tags:   publication.GetTags(),
+		})
+	}
+	return result, nil
+}
+
+func (c *CentrifugoGRPCClient) HistoryRemove(ctx context.Context, channel string) error {
+	resp, err := c.client.HistoryRemove(ctx, &centrifugoapi.HistoryRemoveRequest{Channel: channel})
+	if err != nil {
+		return err
+	}
+	return apiError(resp.GetError())
+}
+
+func (c *CentrifugoGRPCClient) Unsubscribe(ctx context.Context, channel, user string) error {
+	resp, err := c.client.Unsubscribe(ctx, &centrifugoapi.UnsubscribeRequest{Channel: channel, User: user})
+	if err != nil {
+		return err
+	}
+	return apiError(resp.GetError())
+}
+
+func (c *CentrifugoGRPCClient) Disconnect(ctx context.Context, user string) error {
+	resp, err := c.client.Disconnect(ctx, &centrifugoapi.DisconnectRequest{User: user})
+	if err != nil {
+		return err
+	}
+	return apiError(resp.GetError())
+}
+
+func (c *CentrifugoGRPCClient) Info(ctx context.Context) ([]NodeInfo, error) {
+	resp, err := c.client.Info(ctx, &centrifugoapi.InfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if err := apiError(resp.GetError()); err != nil {
+		return nil, err
+	}
+
+	var nodes []NodeInfo
+	for _, node := range resp.GetResult().GetNodes() {
+		nodes = append(nodes, NodeInfo{
+			UID:         node.GetUid(),
+			Name:        node.GetName(),
+			Version:     node.GetVersion(),
+			NumClients:  node.GetNumClients(),
+			NumUsers:    node.GetNumUsers(),
+			NumChannels: node.GetNumChannels(),
+			NumSubs:     node.GetNumSubs(),
+			Uptime:      node.GetUptime(),
+		})
+	}
+	return nodes, nil
+}
+
+func (c *CentrifugoGRPCClient) Channels(ctx context.Context, pattern string) (map[string]ChannelInfo, error) {
+	resp, err := c.client.Channels(ctx, &centrifugoapi.ChannelsRequest{Pattern: pattern})
+	if err != nil {
+		return nil, err
+	}
+	if err := apiError(resp.GetError()); err != nil {
+		return nil, err
+	}
+
+	channels := make(map[string]ChannelInfo, len(resp.GetResult().GetChannels()))
+	for name, info := range resp.GetResult().GetChannels() {
+		channels[name] = ChannelInfo{NumClients: info.GetNumClients()}
+	}
+	return channels, nil
+}
+
+func (c *CentrifugoGRPCClient) broadcastBatch(ctx context.Context, publications []Publication) ([]error, error) {
+	req := &centrifugoapi.BatchRequest{
+		Commands: make([]*centrifugoapi.Command, len(publications)),
+	}
+	for i, publication := range publications {
+		req.Commands[i] = &centrifugoapi.Command{
+			Broadcast: &centrifugoapi.BroadcastRequest{
+				Channels: publication.Channels,
+				Data:     publication.Payload,
+			},
+		}
+	}
+
+	resp, err := c.client.Batch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GetReplies()) != len(publications) {
+		return nil, fmt.Errorf("expected %d replies, got %d", len(publications), len(resp.GetReplies()))
+	}
+
+	errs := make([]error, len(publications))
+	for i, reply := range resp.GetReplies() {
+		if err := apiError(reply.GetError()); err != nil {
+			errs[i] = err
+			continue
+		}
+		errs[i] = firstBroadcastError(grpcBroadcastResponses(publications[i].Channels, reply.GetBroadcast()))
+	}
+	return errs, nil
+}
+
+func apiError(err *centrifugoapi.Error) error {
+	if err == nil {
+		return nil
+	}
+	return &CentrifugoError{Code: err.GetCode(), Message: err.GetMessage()}
+}
+
+func publishResult(result *centrifugoapi.PublishResult) *PublishResult {
+	return &PublishResult{
+		StreamPosition: StreamPosition{
+			Offset: result.GetOffset(),
+			Epoch:  result.GetEpoch(),
+		},
+	}
+}
+
+func grpcBroadcastResponses(channels []string, result *centrifugoapi.BroadcastResult) []BroadcastResponse {
+	responses := make([]BroadcastResponse, 0, len(result.GetResponses()))
+	for i, response := range result.GetResponses() {
+		if i >= len(channels) {
+			break
+		}
+		responses = append(responses, BroadcastResponse{
+			Channel: channels[i],
+			Result:  *publishResult(response.GetResult()),
+			Err:     apiError(response.GetError()),
+		})
+	}
+	return responses
+}
+
+func clientInfo(info *centrifugoapi.ClientInfo) *ClientInfo {
+	if info == nil {
+		return nil
+	}
+	return &ClientInfo{
+		User:     info.GetUser(),
+		Client:   info.GetClient(),
+		ConnInfo: json.RawMessage(info.GetConnInfo()),
+		ChanInfo: json.RawMessage(info.GetChanInfo()),
+	}
+}
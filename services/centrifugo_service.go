@@ -2,124 +2,347 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"github.com/VaheMuradyan/Sport/config"
+	"io"
 	"net/http"
 	"time"
 )
 
+// CentrifugoService is the Centrifugo API client for the HTTP transport. Every
+// method is a POST to /api/<method>, or with the v4 API a POST to /api with
+// the method in the body.
 type CentrifugoService struct {
 	apiURL string
 	apiKey string
+	legacy bool // v4 API
 	client *http.Client
 }
 
-type CentrifugoPublishRequest struct {
-	Method string                `json:"method"`
-	Params CentrifugoPublishData `json:"params"`
+// centrifugoCommand is a request to the v4 API.
+type centrifugoCommand struct {
+	Method string      `json:"method"`
+	Params interface{} `json:"params"`
 }
 
 type CentrifugoPublishData struct {
-	Channel string      `json:"channel"`
-	Data    interface{} `json:"data"`
+	Channel string          `json:"channel"`
+	Data    json.RawMessage `json:"data"`
 }
 
+type CentrifugoBroadcastData struct {
+	Channels []string        `json:"channels"`
+	Data     json.RawMessage `json:"data"`
+}
+
+type centrifugoChannelParams struct {
+	Channel string `json:"channel"`
+}
+
+type centrifugoHistoryParams struct {
+	Channel string `json:"channel"`
+	HistoryOptions
+}
+
+type centrifugoUnsubscribeParams struct {
+	Channel string `json:"channel"`
+	User    string `json:"user"`
+}
+
+type centrifugoDisconnectParams struct {
+	User string `json:"user"`
+}
+
+type centrifugoChannelsParams struct {
+	Pattern string `json:"pattern,omitempty"`
+}
+
+type centrifugoResponse struct {
+	Error  *CentrifugoError `json:"error"`
+	Result json.RawMessage  `json:"result"`
+}
+
+type centrifugoPublishResponse struct {
+	Error  *CentrifugoError `json:"error"`
+	Result PublishResult    `json:"result"`
+}
+
+type centrifugoBroadcastResult struct {
+	Responses []centrifugoPublishResponse `json:"responses"`
+}
+
+// centrifugoBatchRequest is the body of the /api/batch endpoint. Commands
+// are executed in order and answered by replies at the same index.
+type centrifugoBatchRequest struct {
+	Commands []centrifugoBatchCommand `json:"commands"`
+}
+
+type centrifugoBatchCommand struct {
+	Broadcast *CentrifugoBroadcastData `json:"broadcast,omitempty"`
+}
+
+type centrifugoBatchResponse struct {
+	Replies []struct {
+		Error     *CentrifugoError           `json:"error"`
+		Broadcast *centrifugoBroadcastResult `json:"broadcast"`
+	} `json:"replies"`
+}
+
+// CoefficientUpdateMessage announces new odds of a selection. It is published
+// on the channels of the selection's market.
 type CoefficientUpdateMessage struct {
 	Type           string    `json:"type"`
 	MarketID       uint      `json:"market_id"`
+	SelectionID    uint      `json:"selection_id"`
+	Selection      string    `json:"selection"` // name of the selection
+	Line           *float64  `json:"line,omitempty"`
 	EventID        uint      `json:"event_id"`
+	CompetitionID  uint      `json:"competition_id"`
 	OldCoefficient float64   `json:"old_coefficient"`
 	NewCoefficient float64   `json:"new_coefficient"`
 	Timestamp      time.Time `json:"timestamp"`
+	Sequence       uint64    `json:"sequence"` // per event, see EventSnapshot
 	MarketName     string    `json:"market_name,omitempty"`
 	EventName      string    `json:"event_name,omitempty"`
 }
 
-func NewCentrifugoService(apiURL, apiKey string) *CentrifugoService {
+// CoefficientBatchUpdateMessage carries the changes of several selections of one
+// event that were applied together. Each entry has the batch sequence.
+type CoefficientBatchUpdateMessage struct {
+	Type          string                     `json:"type"`
+	EventID       uint                       `json:"event_id"`
+	CompetitionID uint                       `json:"competition_id"`
+	Updates       []CoefficientUpdateMessage `json:"updates"`
+	Timestamp     time.Time                  `json:"timestamp"`
+	Sequence      uint64                     `json:"sequence"` // per event, see EventSnapshot
+}
+
+// NewCentrifugoService creates a client for the given version of the
+// Centrifugo HTTP API. Connections to the API are kept alive and reused
+// between requests.
+func NewCentrifugoService(apiURL, apiKey, apiVersion string) *CentrifugoService {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 32
+
 	return &CentrifugoService{
 		apiURL: apiURL,
 		apiKey: apiKey,
+		legacy: apiVersion == config.CentrifugoAPIV4,
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: transport,
 		},
 	}
 }
 
-func (c *CentrifugoService) PublishCoefficientUpdate(eventID, marketID uint, oldCoeff, newCoeff float64) error {
-	message := CoefficientUpdateMessage{
-		Type:           "coefficient_update",
-		MarketID:       marketID,
-		EventID:        eventID,
-		OldCoefficient: oldCoeff,
-		NewCoefficient: newCoeff,
-		Timestamp:      time.Now(),
+// Publish broadcasts the payload to the channels in a single API call.
+func (c *CentrifugoService) Publish(ctx context.Context, channels []string, payload []byte) error {
+	return publishBroadcast(ctx, c, channels, payload)
+}
+
+func (c *CentrifugoService) Close() error {
+	return nil
+}
+
+func (c *CentrifugoService) PublishChannel(ctx context.Context, channel string, data []byte) (*PublishResult, error) {
+	var result PublishResult
+	if err := c.call(ctx, "publish", CentrifugoPublishData{Channel: channel, Data: data}, &result); err != nil {
+		return nil, err
 	}
+	return &result, nil
+}
 
-	channel := "odds_updates"
+func (c *CentrifugoService) Broadcast(ctx context.Context, channels []string, data []byte) ([]BroadcastResponse, error) {
+	var result centrifugoBroadcastResult
+	if err := c.call(ctx, "broadcast", CentrifugoBroadcastData{Channels: channels, Data: data}, &result); err != nil {
+		return nil, err
+	}
+	return broadcastResponses(channels, result.Responses), nil
+}
 
-	if err := c.publishToChannel(channel, message); err != nil {
-		log.Printf("❌ Failed to publish to channel %s: %v", channel, err)
-		return fmt.Errorf("failed to publish to channel %s: %v", channel, err)
-	} else {
-		log.Printf("✅ Published coefficient update to channel %s: Market %d (%.2f → %.2f)",
-			channel, marketID, oldCoeff, newCoeff)
+func (c *CentrifugoService) Presence(ctx context.Context, channel string) (map[string]ClientInfo, error) {
+	var result struct {
+		Presence map[string]ClientInfo `json:"presence"`
+	}
+	if err := c.call(ctx, "presence", centrifugoChannelParams{Channel: channel}, &result); err != nil {
+		return nil, err
 	}
+	return result.Presence, nil
+}
 
-	return nil
+func (c *CentrifugoService) PresenceStats(ctx context.Context, channel string) (*PresenceStats, error) {
+	var result PresenceStats
+	if err := c.call(ctx, "presence_stats", centrifugoChannelParams{Channel: channel}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
-func (c *CentrifugoService) publishToChannel(channel string, data interface{}) error {
-	request := CentrifugoPublishRequest{
-		Method: "publish",
-		Params: CentrifugoPublishData{
-			Channel: channel,
-			Data:    data,
-		},
+func (c *CentrifugoService) History(ctx context.Context, channel string, opts HistoryOptions) (*HistoryResult, error) {
+	var result HistoryResult
+	if err := c.call(ctx, "history", centrifugoHistoryParams{Channel: channel, HistoryOptions: opts}, &result); err != nil {
+		return nil, err
 	}
+	return &result, nil
+}
 
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request: %v", err)
+func (c *CentrifugoService) HistoryRemove(ctx context.Context, channel string) error {
+	return c.call(ctx, "history_remove", centrifugoChannelParams{Channel: channel}, nil)
+}
+
+func (c *CentrifugoService) Unsubscribe(ctx context.Context, channel, user string) error {
+	return c.call(ctx, "unsubscribe", centrifugoUnsubscribeParams{Channel: channel, User: user}, nil)
+}
+
+func (c *CentrifugoService) Disconnect(ctx context.Context, user string) error {
+	return c.call(ctx, "disconnect", centrifugoDisconnectParams{User: user}, nil)
+}
+
+func (c *CentrifugoService) Info(ctx context.Context) ([]NodeInfo, error) {
+	var result struct {
+		Nodes []NodeInfo `json:"nodes"`
 	}
+	if err := c.call(ctx, "info", struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Nodes, nil
+}
 
-	req, err := http.NewRequest("POST", c.apiURL+"/api", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
+func (c *CentrifugoService) Channels(ctx context.Context, pattern string) (map[string]ChannelInfo, error) {
+	var result struct {
+		Channels map[string]ChannelInfo `json:"channels"`
+	}
+	if err := c.call(ctx, "channels", centrifugoChannelsParams{Pattern: pattern}, &result); err != nil {
+		return nil, err
 	}
+	return result.Channels, nil
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "apikey "+c.apiKey)
+// broadcastBatch sends one broadcast per publication in a single batch
+// request. It returns an error for the whole request, or one error per
+// publication, nil for those that were delivered to every channel.
+func (c *CentrifugoService) broadcastBatch(ctx context.Context, publications []Publication) ([]error, error) {
+	if c.legacy {
+		return c.broadcastStream(ctx, publications)
+	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %v", err)
+	request := centrifugoBatchRequest{
+		Commands: make([]centrifugoBatchCommand, len(publications)),
+	}
+	for i, publication := range publications {
+		request.Commands[i].Broadcast = &CentrifugoBroadcastData{
+			Channels: publication.Channels,
+			Data:     publication.Payload,
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	var result centrifugoBatchResponse
+	if err := c.post(ctx, "batch", request, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Replies) != len(publications) {
+		return nil, fmt.Errorf("expected %d replies, got %d", len(publications), len(result.Replies))
+	}
+
+	errs := make([]error, len(publications))
+	for i, reply := range result.Replies {
+		if reply.Error != nil {
+			errs[i] = reply.Error
+			continue
+		}
+		if reply.Broadcast != nil {
+			errs[i] = firstBroadcastError(broadcastResponses(publications[i].Channels, reply.Broadcast.Responses))
+		}
 	}
+	return errs, nil
+}
 
+// call invokes an API method and decodes its result into result, which may
+// be nil.
+func (c *CentrifugoService) call(ctx context.Context, method string, params, result interface{}) error {
+	var response centrifugoResponse
+	if err := c.post(ctx, method, params, &response); err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	if result == nil || len(response.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to decode %s result: %v", method, err)
+	}
 	return nil
 }
 
-func (c *CentrifugoService) GetChannelInfo(channel string) error {
-	request := map[string]interface{}{
-		"method": "info",
-		"params": map[string]interface{}{
-			"channel": channel,
-		},
+// broadcastStream is broadcastBatch for the v4 API, which takes several
+// commands as one JSON object per line and answers with one reply per line.
+func (c *CentrifugoService) broadcastStream(ctx context.Context, publications []Publication) ([]error, error) {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, publication := range publications {
+		command := centrifugoCommand{
+			Method: "broadcast",
+			Params: CentrifugoBroadcastData{Channels: publication.Channels, Data: publication.Payload},
+		}
+		if err := encoder.Encode(command); err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %v", err)
+		}
 	}
 
-	jsonData, err := json.Marshal(request)
+	errs := make([]error, len(publications))
+	err := c.send(ctx, "/api", body.Bytes(), func(decoder *json.Decoder) error {
+		for i := range publications {
+			var reply centrifugoResponse
+			if err := decoder.Decode(&reply); err != nil {
+				return fmt.Errorf("failed to decode reply %d of %d: %v", i+1, len(publications), err)
+			}
+			if reply.Error != nil {
+				errs[i] = reply.Error
+				continue
+			}
+			var result centrifugoBroadcastResult
+			if err := json.Unmarshal(reply.Result, &result); err != nil {
+				return fmt.Errorf("failed to decode broadcast result: %v", err)
+			}
+			errs[i] = firstBroadcastError(broadcastResponses(publications[i].Channels, result.Responses))
+		}
+		return nil
+	})
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return errs, nil
+}
+
+func (c *CentrifugoService) post(ctx context.Context, method string, body, response interface{}) error {
+	path := "/api/" + method
+	if c.legacy {
+		path = "/api"
+		body = centrifugoCommand{Method: method, Params: body}
 	}
 
-	req, err := http.NewRequest("POST", c.apiURL+"/api", bytes.NewBuffer(jsonData))
+	jsonData, err := json.Marshal(body)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	return c.send(ctx, path, jsonData, func(decoder *json.Decoder) error {
+		if err := decoder.Decode(response); err != nil {
+			return fmt.Errorf("failed to decode response: %v", err)
+		}
+		return nil
+	})
+}
+
+// send POSTs the body to the API path and hands the response to decode.
+func (c *CentrifugoService) send(ctx context.Context, path string, body []byte, decode func(*json.Decoder) error) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.apiURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -127,15 +350,35 @@ func (c *CentrifugoService) GetChannelInfo(channel string) error {
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to send request: %v", err)
 	}
-	defer resp.Body.Close()
+	defer drainAndClose(resp)
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return err
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	log.Printf("📊 Channel %s info: %+v", channel, result)
-	return nil
+	return decode(json.NewDecoder(resp.Body))
+}
+
+func broadcastResponses(channels []string, responses []centrifugoPublishResponse) []BroadcastResponse {
+	result := make([]BroadcastResponse, 0, len(responses))
+	for i, response := range responses {
+		if i >= len(channels) {
+			break
+		}
+		r := BroadcastResponse{Channel: channels[i], Result: response.Result}
+		if response.Error != nil {
+			r.Err = response.Error
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// drainAndClose reads what is left of the body so the connection can be
+// reused.
+func drainAndClose(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
 }
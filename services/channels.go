@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Coefficient updates are published to the channels of the market, its event
+// and its competition in the "odds" Centrifugo namespace, and optionally to
+// the global channel that carries every update.
+const (
+	GlobalOddsChannel = "odds_updates"
+	oddsNamespace     = "odds"
+)
+
+// HistoryProbeChannel is a channel of the "odds" namespace that nothing is
+// published to. Its history shows whether the namespace keeps history.
+const HistoryProbeChannel = oddsNamespace + ":history_probe"
+
+func EventChannel(eventID uint) string {
+	return fmt.Sprintf("%s:event:%d", oddsNamespace, eventID)
+}
+
+func MarketChannel(marketID uint) string {
+	return fmt.Sprintf("%s:market:%d", oddsNamespace, marketID)
+}
+
+func CompetitionChannel(competitionID uint) string {
+	return fmt.Sprintf("%s:competition:%d", oddsNamespace, competitionID)
+}
+
+// CoefficientChannels lists the channels an update of the market is
+// published to.
+func CoefficientChannels(competitionID, eventID, marketID uint, publishGlobal bool) []string {
+	channels := []string{
+		MarketChannel(marketID),
+		EventChannel(eventID),
+	}
+	if competitionID != 0 {
+		channels = append(channels, CompetitionChannel(competitionID))
+	}
+	if publishGlobal {
+		channels = append(channels, GlobalOddsChannel)
+	}
+	return channels
+}
+
+// EventChannels lists the channels an event_status message of the event is
+// published to.
+func EventChannels(competitionID, eventID uint, publishGlobal bool) []string {
+	channels := []string{EventChannel(eventID)}
+	if competitionID != 0 {
+		channels = append(channels, CompetitionChannel(competitionID))
+	}
+	if publishGlobal {
+		channels = append(channels, GlobalOddsChannel)
+	}
+	return channels
+}
+
+// IsOddsChannel reports whether clients may subscribe to the channel.
+func IsOddsChannel(channel string) bool {
+	_, _, ok := ParseOddsChannel(channel)
+	return ok
+}
+
+// ParseOddsChannel splits an odds channel into its kind (event, market or
+// competition) and ID. The global channel has an empty kind.
+func ParseOddsChannel(channel string) (kind string, id uint, ok bool) {
+	if channel == GlobalOddsChannel {
+		return "", 0, true
+	}
+
+	parts := strings.Split(channel, ":")
+	if len(parts) != 3 || parts[0] != oddsNamespace {
+		return "", 0, false
+	}
+	switch parts[1] {
+	case "event", "market", "competition":
+	default:
+		return "", 0, false
+	}
+	parsed, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil || parsed == 0 {
+		return "", 0, false
+	}
+	return parts[1], uint(parsed), true
+}
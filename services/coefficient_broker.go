@@ -0,0 +1,121 @@
+package services
+
+import (
+	"github.com/VaheMuradyan/Sport/proto"
+	"log"
+	"sync"
+)
+
+// CoefficientBroker fans coefficient updates out to in-process subscribers
+// such as gRPC streams. Every subscription has a bounded buffer; a subscriber
+// that falls behind is dropped instead of blocking the publisher.
+type CoefficientBroker struct {
+	mu          sync.RWMutex
+	subscribers map[*CoefficientSubscription]struct{}
+	bufferSize  int
+}
+
+type CoefficientSubscription struct {
+	eventIDs   map[uint32]struct{}
+	marketIDs  map[uint32]struct{}
+	updates    chan *proto.CoefficientUpdateEvent
+	overflowed bool
+}
+
+func NewCoefficientBroker(bufferSize int) *CoefficientBroker {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	return &CoefficientBroker{
+		subscribers: make(map[*CoefficientSubscription]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe registers a subscription for updates of the given events and
+// markets. An update matches when its event or its market is listed; with
+// both lists empty every update matches.
+func (b *CoefficientBroker) Subscribe(eventIDs, marketIDs []uint32) *CoefficientSubscription {
+	sub := &CoefficientSubscription{
+		eventIDs:  toIDSet(eventIDs),
+		marketIDs: toIDSet(marketIDs),
+		updates:   make(chan *proto.CoefficientUpdateEvent, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+// Unsubscribe removes the subscription and closes its channel. It is safe to
+// call more than once.
+func (b *CoefficientBroker) Unsubscribe(sub *CoefficientSubscription) {
+	b.remove(sub, false)
+}
+
+func (b *CoefficientBroker) Publish(event *proto.CoefficientUpdateEvent) {
+	var slow []*CoefficientSubscription
+
+	b.mu.RLock()
+	for sub := range b.subscribers {
+		if !sub.matches(event) {
+			continue
+		}
+		select {
+		case sub.updates <- event:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range slow {
+		log.Printf("⚠️ Dropping slow coefficient subscriber: buffer of %d updates is full", b.bufferSize)
+		b.remove(sub, true)
+	}
+}
+
+func (b *CoefficientBroker) remove(sub *CoefficientSubscription, overflowed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	sub.overflowed = overflowed
+	close(sub.updates)
+}
+
+// Updates returns the channel of matching updates. It is closed when the
+// subscription is removed from the broker.
+func (s *CoefficientSubscription) Updates() <-chan *proto.CoefficientUpdateEvent {
+	return s.updates
+}
+
+// Overflowed reports whether the subscription was dropped because its buffer
+// filled up. Only meaningful once Updates has been closed.
+func (s *CoefficientSubscription) Overflowed() bool {
+	return s.overflowed
+}
+
+func (s *CoefficientSubscription) matches(event *proto.CoefficientUpdateEvent) bool {
+	if len(s.eventIDs) == 0 && len(s.marketIDs) == 0 {
+		return true
+	}
+	if _, ok := s.eventIDs[event.EventId]; ok {
+		return true
+	}
+	_, ok := s.marketIDs[event.MarketId]
+	return ok
+}
+
+func toIDSet(ids []uint32) map[uint32]struct{} {
+	set := make(map[uint32]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
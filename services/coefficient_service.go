@@ -1,72 +1,635 @@
 package services
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"github.com/VaheMuradyan/Sport/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
 	"time"
 )
 
+var (
+	ErrMarketNotFound         = errors.New("market not found")
+	ErrSelectionNotFound      = errors.New("selection not found")
+	ErrAmbiguousSelection     = errors.New("market has several selections")
+	ErrCoefficientOutOfBounds = errors.New("coefficient out of bounds")
+	ErrUnknownChannel         = errors.New("unknown channel")
+	ErrVersionConflict        = errors.New("version conflict")
+	ErrInvalidBatch           = errors.New("invalid batch")
+)
+
+// VersionConflictError is returned when an update expected another version of
+// a market or, for odds changes, of a selection. Market holds the current
+// state of the market and its selections so the caller can retry; Selection
+// is the conflicting selection, nil for market changes.
+type VersionConflictError struct {
+	ExpectedVersion uint64
+	Market          *models.Market
+	Selection       *models.Selection
+}
+
+func (e *VersionConflictError) Error() string {
+	if e.Selection != nil {
+		return fmt.Sprintf("%v: selection %d is at version %d with odds %.2f, expected version %d",
+			ErrVersionConflict, e.Selection.ID, e.Selection.Version, e.Selection.CurrentCoefficient, e.ExpectedVersion)
+	}
+	return fmt.Sprintf("%v: market %d is at version %d, expected version %d",
+		ErrVersionConflict, e.Market.ID, e.Market.Version, e.ExpectedVersion)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// CoefficientService applies coefficient changes. With publishGlobal set
+// every update is also published to GlobalOddsChannel.
 type CoefficientService struct {
-	db *gorm.DB
+	db            *gorm.DB
+	permissions   *PermissionService
+	publishGlobal bool
 }
 
-func NewCoefficientService(db *gorm.DB) *CoefficientService {
+func NewCoefficientService(db *gorm.DB, permissions *PermissionService, publishGlobal bool) *CoefficientService {
 	return &CoefficientService{
-		db: db,
+		db:            db,
+		permissions:   permissions,
+		publishGlobal: publishGlobal,
 	}
 }
 
-func (s *CoefficientService) UpdateMarketCoefficient(marketID uint, newCoefficient float64, userID uint) (*models.CoefficientUpdateResponse, error) {
-	var market models.Market
-	if err := s.db.First(&market, marketID).Error; err != nil {
-		return nil, fmt.Errorf("market not found: %v", err)
+// UpdateSelectionCoefficient sets the odds of the selection. With
+// expectedVersion set the update is only applied if the selection is still at
+// that version.
+func (s *CoefficientService) UpdateSelectionCoefficient(selectionID uint, newCoefficient float64, userID uint, expectedVersion *uint64) (*models.CoefficientUpdateResponse, error) {
+	selection, err := s.GetSelection(selectionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkChange(selection, newCoefficient, userID); err != nil {
+		return nil, err
+	}
+	market := selection.Market
+
+	var event models.Event
+	if err := s.db.Select("id", "competition_id").First(&event, market.EventID).Error; err != nil {
+		return nil, fmt.Errorf("failed to load event %d: %v", market.EventID, err)
+	}
+
+	tx := s.db.Begin()
+
+	// Bumping the event sequence locks the event row until commit, so updates
+	// of one event are applied and published in sequence order and snapshots
+	// never see a sequence without its update.
+	sequence, err := nextEventSequence(tx, market.EventID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	change := CoefficientChange{SelectionID: selectionID, NewCoefficient: newCoefficient, ExpectedVersion: expectedVersion}
+	response, err := s.applyChange(tx, change, userID, sequence, time.Now())
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	response.CompetitionID = event.CompetitionID
+
+	// The publication commits or rolls back with the price change.
+	message := coefficientUpdateMessage(response)
+	channels := CoefficientChannels(event.CompetitionID, market.EventID, market.ID, s.publishGlobal)
+	if err := enqueueOutbox(tx, channels, message); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit coefficient update: %v", err)
+	}
+
+	return response, nil
+}
+
+// CoefficientChange is the new odds of one selection in a batch update.
+type CoefficientChange struct {
+	SelectionID     uint
+	NewCoefficient  float64
+	ExpectedVersion *uint64
+}
+
+// UpdateSelectionCoefficients sets the odds of several selections of one
+// event at once, for example home, draw and away of a match winner market.
+// Either every change is applied or none is. The changes share one event
+// sequence and timestamp and are published as a single
+// coefficient_batch_update message, so clients never see half of them.
+func (s *CoefficientService) UpdateSelectionCoefficients(changes []CoefficientChange, userID uint) (*models.BatchCoefficientUpdateResponse, error) {
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: no changes", ErrInvalidBatch)
 	}
 
-	if newCoefficient < market.MinCoefficient || newCoefficient > market.MaxCoefficient {
-		return nil, fmt.Errorf("odds %.2f out of bounds [%.2f, %.2f]", newCoefficient, market.MinCoefficient, market.MaxCoefficient)
+	selectionIDs := make([]uint, len(changes))
+	seen := make(map[uint]bool, len(changes))
+	for i, change := range changes {
+		if seen[change.SelectionID] {
+			return nil, fmt.Errorf("%w: selection %d is changed more than once", ErrInvalidBatch, change.SelectionID)
+		}
+		seen[change.SelectionID] = true
+		selectionIDs[i] = change.SelectionID
 	}
 
-	oldCoefficient := market.CurrentCoefficient
+	var selections []models.Selection
+	if err := s.db.Preload("Market").Where("id IN ?", selectionIDs).Find(&selections).Error; err != nil {
+		return nil, fmt.Errorf("failed to load selections: %v", err)
+	}
+	selectionsByID := make(map[uint]*models.Selection, len(selections))
+	for i := range selections {
+		selectionsByID[selections[i].ID] = &selections[i]
+	}
+
+	eventID := uint(0)
+	for _, change := range changes {
+		selection, ok := selectionsByID[change.SelectionID]
+		if !ok || selection.Market == nil {
+			return nil, fmt.Errorf("selection %d: %w", change.SelectionID, ErrSelectionNotFound)
+		}
+		if eventID == 0 {
+			eventID = selection.Market.EventID
+		} else if selection.Market.EventID != eventID {
+			return nil, fmt.Errorf("%w: selections %d and %d belong to different events", ErrInvalidBatch, selectionIDs[0], selection.ID)
+		}
+		if err := s.checkChange(selection, change.NewCoefficient, userID); err != nil {
+			return nil, fmt.Errorf("selection %d: %w", selection.ID, err)
+		}
+	}
+
+	var event models.Event
+	if err := s.db.Select("id", "competition_id").First(&event, eventID).Error; err != nil {
+		return nil, fmt.Errorf("failed to load event %d: %v", eventID, err)
+	}
 
 	tx := s.db.Begin()
 
-	market.PreviousCoefficient = oldCoefficient
-	market.CurrentCoefficient = newCoefficient
-	market.LastUpdated = time.Now()
+	sequence, err := nextEventSequence(tx, eventID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Selections are locked in ID order so batches overlapping in selections
+	// cannot deadlock.
+	ordered := slices.Clone(changes)
+	slices.SortFunc(ordered, func(a, b CoefficientChange) int {
+		return cmp.Compare(a.SelectionID, b.SelectionID)
+	})
+
+	now := time.Now()
+	batch := &models.BatchCoefficientUpdateResponse{
+		Success:       true,
+		Message:       "Coefficients updated successfully",
+		EventID:       eventID,
+		CompetitionID: event.CompetitionID,
+		UpdatedAt:     now,
+		Sequence:      sequence,
+		Updates:       make([]models.CoefficientUpdateResponse, 0, len(ordered)),
+	}
+	message := CoefficientBatchUpdateMessage{
+		Type:          "coefficient_batch_update",
+		EventID:       eventID,
+		CompetitionID: event.CompetitionID,
+		Updates:       make([]CoefficientUpdateMessage, 0, len(ordered)),
+		Timestamp:     now,
+		Sequence:      sequence,
+	}
+	channels := []string{}
+	for _, change := range ordered {
+		response, err := s.applyChange(tx, change, userID, sequence, now)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("selection %d: %w", change.SelectionID, err)
+		}
+		response.CompetitionID = event.CompetitionID
+
+		batch.Updates = append(batch.Updates, *response)
+		message.Updates = append(message.Updates, coefficientUpdateMessage(response))
+		// Channels keep the order of CoefficientChannels, so the first
+		// market channel, which keyed backends partition by, comes first.
+		for _, channel := range CoefficientChannels(event.CompetitionID, eventID, response.MarketID, s.publishGlobal) {
+			if !slices.Contains(channels, channel) {
+				channels = append(channels, channel)
+			}
+		}
+	}
 
-	if err := tx.Save(&market).Error; err != nil {
+	if err := enqueueOutbox(tx, channels, message); err != nil {
 		tx.Rollback()
-		return nil, fmt.Errorf("failed to update market: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit coefficient updates: %v", err)
+	}
+
+	return batch, nil
+}
+
+// checkChange reports whether the user may set the odds of the selection,
+// which must have its market loaded.
+func (s *CoefficientService) checkChange(selection *models.Selection, newCoefficient float64, userID uint) error {
+	if err := s.permissions.CanTradeMarket(userID, selection.Market); err != nil {
+		return err
+	}
+	if newCoefficient < selection.MinCoefficient || newCoefficient > selection.MaxCoefficient {
+		return fmt.Errorf("%w: odds %.2f out of bounds [%.2f, %.2f]", ErrCoefficientOutOfBounds, newCoefficient, selection.MinCoefficient, selection.MaxCoefficient)
+	}
+	return nil
+}
+
+// ValidateSelection checks a selection written through the catalogue. Its
+// market must exist and its odds must be within its bounds; the odds are only
+// set on create, later changes go through UpdateSelectionCoefficient.
+func ValidateSelection(db *gorm.DB, selection *models.Selection) error {
+	if selection.CurrentCoefficient < selection.MinCoefficient || selection.CurrentCoefficient > selection.MaxCoefficient {
+		return fmt.Errorf("%w: odds %.2f out of bounds [%.2f, %.2f]", ErrCoefficientOutOfBounds, selection.CurrentCoefficient, selection.MinCoefficient, selection.MaxCoefficient)
+	}
+
+	var count int64
+	if err := db.Model(&models.Market{}).Where("id = ?", selection.MarketID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check market: %v", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %d", ErrMarketNotFound, selection.MarketID)
+	}
+	return nil
+}
+
+// applyChange writes the new odds of a selection and its history entry in tx.
+// The event sequence must already have been bumped in tx.
+func (s *CoefficientService) applyChange(tx *gorm.DB, change CoefficientChange, userID uint, sequence uint64, now time.Time) (*models.CoefficientUpdateResponse, error) {
+	// The old value and the version check must see the latest committed row.
+	var selection models.Selection
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&selection, change.SelectionID).Error; err != nil {
+		return nil, fmt.Errorf("failed to reload selection: %v", err)
+	}
+	if change.ExpectedVersion != nil && selection.Version != *change.ExpectedVersion {
+		return nil, selectionConflict(tx, *change.ExpectedVersion, &selection)
+	}
+
+	// Status changes bump the event sequence too, so the status cannot change
+	// before commit.
+	var market models.Market
+	if err := tx.Select("id", "event_id", "status", "line").First(&market, selection.MarketID).Error; err != nil {
+		return nil, fmt.Errorf("failed to load market: %v", err)
+	}
+	if market.Status != models.MarketOpen {
+		return nil, fmt.Errorf("%w: market %d is %s", ErrMarketNotOpen, market.ID, market.Status)
+	}
+
+	oldCoefficient := selection.CurrentCoefficient
+	version := selection.Version
+	selection.PreviousCoefficient = oldCoefficient
+	selection.CurrentCoefficient = change.NewCoefficient
+	selection.LastUpdated = now
+	selection.Version = version + 1
+
+	result := tx.Model(&models.Selection{}).
+		Where("id = ? AND version = ?", selection.ID, version).
+		Updates(map[string]interface{}{
+			"previous_coefficient": selection.PreviousCoefficient,
+			"current_coefficient":  selection.CurrentCoefficient,
+			"last_updated":         selection.LastUpdated,
+			"version":              selection.Version,
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update selection: %v", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		var current models.Selection
+		if err := tx.First(&current, selection.ID).Error; err != nil {
+			return nil, fmt.Errorf("failed to reload selection: %v", err)
+		}
+		return nil, selectionConflict(tx, version, &current)
 	}
 
 	coefficientHistory := models.CoefficientHistory{
-		MarketID:    marketID,
+		MarketID:    market.ID,
+		SelectionID: selection.ID,
+		Line:        market.Line,
 		OldValue:    oldCoefficient,
-		NewValue:    newCoefficient,
+		NewValue:    change.NewCoefficient,
 		ChangedByID: userID,
-		Timestamp:   time.Now(),
+		Timestamp:   selection.LastUpdated,
+		Sequence:    sequence,
 	}
-
 	if err := tx.Create(&coefficientHistory).Error; err != nil {
-		tx.Rollback()
 		return nil, fmt.Errorf("failed to record coefficient history: %v", err)
 	}
 
-	tx.Commit()
-
 	return &models.CoefficientUpdateResponse{
 		Success:        true,
 		Message:        "Coefficient updated successfully",
-		MarketID:       marketID,
+		MarketID:       market.ID,
+		SelectionID:    selection.ID,
+		Selection:      selection.Name,
+		Line:           market.Line,
+		EventID:        market.EventID,
 		OldCoefficient: oldCoefficient,
-		NewCoefficient: newCoefficient,
-		UpdatedAt:      market.LastUpdated,
+		NewCoefficient: change.NewCoefficient,
+		UpdatedAt:      selection.LastUpdated,
+		Sequence:       sequence,
+		Version:        selection.Version,
 	}, nil
 }
 
+// selectionConflict builds the VersionConflictError of a selection with its
+// market as of tx.
+func selectionConflict(tx *gorm.DB, expectedVersion uint64, selection *models.Selection) error {
+	market, err := loadMarket(tx, selection.MarketID)
+	if err != nil {
+		return err
+	}
+	return &VersionConflictError{ExpectedVersion: expectedVersion, Market: market, Selection: selection}
+}
+
+func coefficientUpdateMessage(response *models.CoefficientUpdateResponse) CoefficientUpdateMessage {
+	return CoefficientUpdateMessage{
+		Type:           "coefficient_update",
+		MarketID:       response.MarketID,
+		SelectionID:    response.SelectionID,
+		Selection:      response.Selection,
+		Line:           response.Line,
+		EventID:        response.EventID,
+		CompetitionID:  response.CompetitionID,
+		OldCoefficient: response.OldCoefficient,
+		NewCoefficient: response.NewCoefficient,
+		Timestamp:      response.UpdatedAt,
+		Sequence:       response.Sequence,
+	}
+}
+
+// nextEventSequence increments the sequence of the event in tx and returns
+// the new value.
+func nextEventSequence(tx *gorm.DB, eventID uint) (uint64, error) {
+	err := tx.Model(&models.Event{}).
+		Where("id = ?", eventID).
+		UpdateColumn("sequence", gorm.Expr("sequence + 1")).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment event sequence: %v", err)
+	}
+
+	var sequence uint64
+	if err := tx.Model(&models.Event{}).Where("id = ?", eventID).Pluck("sequence", &sequence).Error; err != nil {
+		return 0, fmt.Errorf("failed to read event sequence: %v", err)
+	}
+	return sequence, nil
+}
+
 func (s *CoefficientService) GetMarketWithHistory(marketID uint) (*models.Market, error) {
 	var market models.Market
-	err := s.db.Preload("CoefficientHistory").Preload("Event").First(&market, marketID).Error
+	err := s.db.Preload("CoefficientHistory").Preload("Event").Preload("Selections").First(&market, marketID).Error
 	return &market, err
 }
+
+// GetMarket returns the market with its selections.
+func (s *CoefficientService) GetMarket(marketID uint) (*models.Market, error) {
+	return loadMarket(s.db, marketID)
+}
+
+func loadMarket(db *gorm.DB, marketID uint) (*models.Market, error) {
+	var market models.Market
+	err := db.Preload("Selections", func(tx *gorm.DB) *gorm.DB { return tx.Order("id") }).
+		First(&market, marketID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMarketNotFound
+		}
+		return nil, fmt.Errorf("failed to load market: %v", err)
+	}
+	return &market, nil
+}
+
+// GetSelection returns the selection with its market.
+func (s *CoefficientService) GetSelection(selectionID uint) (*models.Selection, error) {
+	var selection models.Selection
+	if err := s.db.Preload("Market").First(&selection, selectionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSelectionNotFound
+		}
+		return nil, fmt.Errorf("failed to load selection: %v", err)
+	}
+	if selection.Market == nil {
+		return nil, ErrSelectionNotFound
+	}
+	return &selection, nil
+}
+
+// MarketSelection returns the only selection of the market. It serves
+// clients from before selections, which address odds by market.
+func (s *CoefficientService) MarketSelection(marketID uint) (*models.Selection, error) {
+	market, err := s.GetMarket(marketID)
+	if err != nil {
+		return nil, err
+	}
+	switch len(market.Selections) {
+	case 0:
+		return nil, fmt.Errorf("market %d: %w", marketID, ErrSelectionNotFound)
+	case 1:
+		selection := market.Selections[0]
+		selection.Market = market
+		return &selection, nil
+	default:
+		return nil, fmt.Errorf("%w: market %d has %d, address one by selection_id", ErrAmbiguousSelection, marketID, len(market.Selections))
+	}
+}
+
+// HistoryQuery selects the coefficient history replayed for a channel:
+// entries after the AfterID offset or, when Since is set, entries at or after
+// Since.
+type HistoryQuery struct {
+	Channel string
+	Since   time.Time
+	AfterID uint
+	Limit   int
+}
+
+// ReplayedUpdate is a coefficient history entry in the shape of the update
+// that was published for it. Offset is the ID of the entry.
+type ReplayedUpdate struct {
+	Offset uint `json:"offset"`
+	CoefficientUpdateMessage
+}
+
+// ReplayHistory returns the coefficient changes published to the channel in
+// the order they were made, so a client that missed publications can rebuild
+// its state.
+func (s *CoefficientService) ReplayHistory(query HistoryQuery) ([]ReplayedUpdate, error) {
+	kind, id, ok := ParseOddsChannel(query.Channel)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownChannel, query.Channel)
+	}
+
+	db := s.db.Table("coefficient_histories").
+		Select("coefficient_histories.id, coefficient_histories.market_id, coefficient_histories.selection_id, " +
+			"selections.name AS selection, coefficient_histories.line, markets.event_id, events.competition_id, " +
+			"coefficient_histories.old_value, coefficient_histories.new_value, coefficient_histories.timestamp, coefficient_histories.sequence").
+		Joins("JOIN markets ON markets.id = coefficient_histories.market_id").
+		Joins("LEFT JOIN selections ON selections.id = coefficient_histories.selection_id").
+		Joins("JOIN events ON events.id = markets.event_id").
+		Where("coefficient_histories.deleted_at IS NULL")
+
+	switch kind {
+	case "market":
+		db = db.Where("coefficient_histories.market_id = ?", id)
+	case "event":
+		db = db.Where("markets.event_id = ?", id)
+	case "competition":
+		db = db.Where("events.competition_id = ?", id)
+	}
+
+	if !query.Since.IsZero() {
+		db = db.Where("coefficient_histories.timestamp >= ?", query.Since)
+	} else {
+		db = db.Where("coefficient_histories.id > ?", query.AfterID)
+	}
+
+	var rows []struct {
+		ID            uint
+		MarketID      uint
+		SelectionID   uint
+		Selection     string
+		Line          *float64
+		EventID       uint
+		CompetitionID uint
+		OldValue      float64
+		NewValue      float64
+		Timestamp     time.Time
+		Sequence      uint64
+	}
+	if err := db.Order("coefficient_histories.id").Limit(query.Limit).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load coefficient history: %v", err)
+	}
+
+	updates := make([]ReplayedUpdate, len(rows))
+	for i, row := range rows {
+		updates[i] = ReplayedUpdate{
+			Offset: row.ID,
+			CoefficientUpdateMessage: CoefficientUpdateMessage{
+				Type:           "coefficient_update",
+				MarketID:       row.MarketID,
+				SelectionID:    row.SelectionID,
+				Selection:      row.Selection,
+				Line:           row.Line,
+				EventID:        row.EventID,
+				CompetitionID:  row.CompetitionID,
+				OldCoefficient: row.OldValue,
+				NewCoefficient: row.NewValue,
+				Timestamp:      row.Timestamp,
+				Sequence:       row.Sequence,
+			},
+		}
+	}
+	return updates, nil
+}
+
+// MarketSnapshot is the current line and prices of a market.
+type MarketSnapshot struct {
+	MarketID   uint                `json:"market_id"`
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Line       *float64            `json:"line,omitempty"`
+	Period     string              `json:"period,omitempty"`
+	Player     string              `json:"player,omitempty"`
+	TeamID     *uint               `json:"team_id,omitempty"`
+	Status     string              `json:"status"`
+	Selections []SelectionSnapshot `json:"selections"`
+}
+
+// SelectionSnapshot is the current price of a selection.
+type SelectionSnapshot struct {
+	SelectionID         uint      `json:"selection_id"`
+	Name                string    `json:"name"`
+	CurrentCoefficient  float64   `json:"current_coefficient"`
+	PreviousCoefficient float64   `json:"previous_coefficient"`
+	LastUpdated         time.Time `json:"last_updated"`
+}
+
+// EventSnapshot is the board of an event: its open and suspended markets as
+// of update Sequence. A client applies published updates with a greater
+// sequence on top of it and drops the others.
+type EventSnapshot struct {
+	EventID       uint             `json:"event_id"`
+	CompetitionID uint             `json:"competition_id"`
+	Sequence      uint64           `json:"sequence"`
+	Status        string           `json:"status"`
+	IsLive        bool             `json:"is_live"`
+	Markets       []MarketSnapshot `json:"markets"`
+	Timestamp     time.Time        `json:"timestamp"`
+}
+
+// GetEventSnapshot returns the open and suspended markets of the event with
+// the sequence of the last update applied to them.
+func (s *CoefficientService) GetEventSnapshot(eventID uint) (*EventSnapshot, error) {
+	var snapshot *EventSnapshot
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// The shared lock waits for updates of the event in flight, which hold
+		// the row until they commit.
+		var event models.Event
+		err := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+			Select("id", "competition_id", "sequence", "status", "is_live").
+			First(&event, eventID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrEventNotFound
+			}
+			return fmt.Errorf("failed to load event: %v", err)
+		}
+
+		var markets []models.Market
+		err = tx.Preload("Selections", func(tx *gorm.DB) *gorm.DB { return tx.Order("id") }).
+			Where("event_id = ? AND status IN ?", eventID, []string{models.MarketOpen, models.MarketSuspended}).
+			Order("id").
+			Find(&markets).Error
+		if err != nil {
+			return fmt.Errorf("failed to load markets: %v", err)
+		}
+
+		snapshot = &EventSnapshot{
+			EventID:       event.ID,
+			CompetitionID: event.CompetitionID,
+			Sequence:      event.Sequence,
+			Status:        event.Status,
+			IsLive:        event.IsLive,
+			Markets:       make([]MarketSnapshot, len(markets)),
+			Timestamp:     time.Now(),
+		}
+		for i, market := range markets {
+			selections := make([]SelectionSnapshot, len(market.Selections))
+			for j, selection := range market.Selections {
+				selections[j] = SelectionSnapshot{
+					SelectionID:         selection.ID,
+					Name:                selection.Name,
+					CurrentCoefficient:  selection.CurrentCoefficient,
+					PreviousCoefficient: selection.PreviousCoefficient,
+					LastUpdated:         selection.LastUpdated,
+				}
+			}
+			snapshot.Markets[i] = MarketSnapshot{
+				MarketID:   market.ID,
+				Name:       market.Name,
+				Type:       market.Type,
+				Line:       market.Line,
+				Period:     market.Period,
+				Player:     market.Player,
+				TeamID:     market.TeamID,
+				Status:     market.Status,
+				Selections: selections,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
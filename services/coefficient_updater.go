@@ -0,0 +1,161 @@
+package services
+
+import (
+	"github.com/VaheMuradyan/Sport/models"
+	"github.com/VaheMuradyan/Sport/proto"
+)
+
+// CoefficientUpdater applies a coefficient change and announces it to
+// in-process stream subscribers. The Centrifugo publication is written to the
+// outbox with the change and delivered by the outbox dispatcher. The gRPC and
+// HTTP APIs both go through it so every update is published the same way.
+type CoefficientUpdater struct {
+	coefficientService *CoefficientService
+	outbox             *OutboxDispatcher
+	broker             *CoefficientBroker
+}
+
+func NewCoefficientUpdater(coefficientService *CoefficientService, outbox *OutboxDispatcher, broker *CoefficientBroker) *CoefficientUpdater {
+	return &CoefficientUpdater{
+		coefficientService: coefficientService,
+		outbox:             outbox,
+		broker:             broker,
+	}
+}
+
+func (u *CoefficientUpdater) UpdateCoefficient(selectionID uint, newCoefficient float64, userID uint, expectedVersion *uint64) (*models.CoefficientUpdateResponse, error) {
+	response, err := u.coefficientService.UpdateSelectionCoefficient(selectionID, newCoefficient, userID, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	u.outbox.Notify()
+	u.announce(response)
+
+	return response, nil
+}
+
+// UpdateCoefficients applies the changes atomically, see
+// CoefficientService.UpdateSelectionCoefficients. Stream subscribers receive
+// one update per selection, all with the batch sequence.
+func (u *CoefficientUpdater) UpdateCoefficients(changes []CoefficientChange, userID uint) (*models.BatchCoefficientUpdateResponse, error) {
+	response, err := u.coefficientService.UpdateSelectionCoefficients(changes, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.outbox.Notify()
+	for i := range response.Updates {
+		u.announce(&response.Updates[i])
+	}
+
+	return response, nil
+}
+
+// SetMarketStatus changes the status of a market, see
+// CoefficientService.SetMarketStatus.
+func (u *CoefficientUpdater) SetMarketStatus(marketID uint, status, reason string, userID uint, expectedVersion *uint64) (*models.MarketStatusResponse, error) {
+	response, err := u.coefficientService.SetMarketStatus(marketID, status, reason, userID, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	u.outbox.Notify()
+	u.announceStatus(response)
+
+	return response, nil
+}
+
+// MoveMarketLine moves the line of a market with the odds quoted at it, see
+// CoefficientService.MoveMarketLine. Stream subscribers receive a market_line
+// event followed by one update per selection, all with the same sequence.
+func (u *CoefficientUpdater) MoveMarketLine(marketID uint, line float64, changes []CoefficientChange, userID uint, expectedVersion *uint64) (*models.MarketLineResponse, error) {
+	response, err := u.coefficientService.MoveMarketLine(marketID, line, changes, userID, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	u.outbox.Notify()
+	u.broker.Publish(&proto.CoefficientUpdateEvent{
+		Type:         "market_line",
+		MarketId:     uint32(response.MarketID),
+		EventId:      uint32(response.EventID),
+		Timestamp:    response.UpdatedAt.Unix(),
+		Sequence:     response.Sequence,
+		Line:         &response.Line,
+		PreviousLine: response.PreviousLine,
+	})
+	for i := range response.Updates {
+		u.announce(&response.Updates[i])
+	}
+
+	return response, nil
+}
+
+// SetEventStatus changes the status of an event and of the markets that
+// follow it, see CoefficientService.SetEventStatus.
+func (u *CoefficientUpdater) SetEventStatus(eventID uint, status, reason string, userID uint) (*models.EventStatusResponse, error) {
+	response, err := u.coefficientService.SetEventStatus(eventID, status, reason, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.outbox.Notify()
+	u.broker.Publish(&proto.CoefficientUpdateEvent{
+		Type:      "event_status",
+		EventId:   uint32(response.EventID),
+		Timestamp: response.UpdatedAt.Unix(),
+		Sequence:  response.Sequence,
+		Status:    response.Status,
+	})
+	for i := range response.Markets {
+		u.announceStatus(&response.Markets[i])
+	}
+
+	return response, nil
+}
+
+// RecordIncident records an incident of a live event, see
+// CoefficientService.RecordIncident.
+func (u *CoefficientUpdater) RecordIncident(eventID uint, req models.IncidentRequest, userID uint) (*IncidentMessage, error) {
+	message, err := u.coefficientService.RecordIncident(eventID, req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.outbox.Notify()
+	u.broker.Publish(&proto.CoefficientUpdateEvent{
+		Type:      "incident",
+		EventId:   uint32(message.EventID),
+		Timestamp: message.Timestamp.Unix(),
+		Sequence:  message.Sequence,
+		Incident:  incidentProto(message),
+	})
+
+	return message, nil
+}
+
+func (u *CoefficientUpdater) announceStatus(response *models.MarketStatusResponse) {
+	u.broker.Publish(&proto.CoefficientUpdateEvent{
+		Type:      "market_status",
+		MarketId:  uint32(response.MarketID),
+		EventId:   uint32(response.EventID),
+		Timestamp: response.UpdatedAt.Unix(),
+		Sequence:  response.Sequence,
+		Status:    response.Status,
+	})
+}
+
+func (u *CoefficientUpdater) announce(response *models.CoefficientUpdateResponse) {
+	u.broker.Publish(&proto.CoefficientUpdateEvent{
+		Type:           "coefficient_update",
+		MarketId:       uint32(response.MarketID),
+		SelectionId:    uint32(response.SelectionID),
+		EventId:        uint32(response.EventID),
+		Line:           response.Line,
+		OldCoefficient: response.OldCoefficient,
+		NewCoefficient: response.NewCoefficient,
+		Timestamp:      response.UpdatedAt.Unix(),
+		Sequence:       response.Sequence,
+	})
+}
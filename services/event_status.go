@@ -0,0 +1,180 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"gorm.io/gorm"
+	"slices"
+	"time"
+)
+
+// eventTransitions lists the statuses an event may move to from each status.
+var eventTransitions = map[string][]string{
+	models.EventScheduled: {models.EventPreMatch, models.EventLive, models.EventPostponed, models.EventCancelled},
+	models.EventPreMatch:  {models.EventLive, models.EventPostponed, models.EventCancelled},
+	models.EventLive:      {models.EventHalfTime, models.EventFinished, models.EventCancelled},
+	models.EventHalfTime:  {models.EventLive, models.EventCancelled},
+	models.EventPostponed: {models.EventScheduled, models.EventCancelled},
+	models.EventFinished:  {},
+	models.EventCancelled: {},
+}
+
+// eventMarketTransitions is what happens to the markets of an event when it
+// enters a status: markets in one of the from statuses are moved to the
+// market status. Going live suspends the pre-match prices until traders
+// reopen the markets in play.
+var eventMarketTransitions = map[string]struct {
+	from []string
+	to   string
+}{
+	models.EventLive:      {from: []string{models.MarketOpen}, to: models.MarketSuspended},
+	models.EventPostponed: {from: []string{models.MarketOpen}, to: models.MarketSuspended},
+	models.EventFinished:  {from: []string{models.MarketOpen, models.MarketSuspended}, to: models.MarketClosed},
+	models.EventCancelled: {from: []string{models.MarketOpen, models.MarketSuspended, models.MarketClosed}, to: models.MarketVoided},
+}
+
+// CanTransitionEvent reports whether an event may move from one status to
+// another.
+func CanTransitionEvent(from, to string) bool {
+	return slices.Contains(eventTransitions[from], to)
+}
+
+// EventAllowsTrading reports whether markets of an event in the status may be
+// opened.
+func EventAllowsTrading(status string) bool {
+	return status != models.EventFinished && status != models.EventCancelled
+}
+
+// EventStatusMessage announces that an event changed status. The
+// market_status messages of the markets that changed with it follow with the
+// same sequence.
+type EventStatusMessage struct {
+	Type           string    `json:"type"`
+	EventID        uint      `json:"event_id"`
+	CompetitionID  uint      `json:"competition_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	IsLive         bool      `json:"is_live"`
+	Reason         string    `json:"reason,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+	Sequence       uint64    `json:"sequence"` // per event, see EventSnapshot
+}
+
+// SetEventStatus moves the event to status, suspends, closes or voids its
+// markets as the new status requires and publishes the changes.
+func (s *CoefficientService) SetEventStatus(eventID uint, status, reason string, userID uint) (*models.EventStatusResponse, error) {
+	if _, ok := eventTransitions[status]; !ok {
+		return nil, fmt.Errorf("%w: unknown event status %q", ErrInvalidTransition, status)
+	}
+
+	var event models.Event
+	if err := s.db.Select("id", "competition_id").First(&event, eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to load event: %v", err)
+	}
+	if err := s.permissions.CanChangeEventStatus(userID, &event); err != nil {
+		return nil, err
+	}
+
+	tx := s.db.Begin()
+
+	sequence, err := nextEventSequence(tx, eventID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	// The sequence bump holds the event row, so the status read here cannot
+	// change before commit.
+	if err := tx.Select("id", "competition_id", "status").First(&event, eventID).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to reload event: %v", err)
+	}
+	if !CanTransitionEvent(event.Status, status) {
+		tx.Rollback()
+		return nil, fmt.Errorf("%w: event %d cannot move from %s to %s", ErrInvalidTransition, eventID, event.Status, status)
+	}
+
+	now := time.Now()
+	isLive := status == models.EventLive || status == models.EventHalfTime
+	err = tx.Model(&models.Event{}).Where("id = ?", eventID).
+		Updates(map[string]interface{}{"status": status, "is_live": isLive}).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update event status: %v", err)
+	}
+
+	response := &models.EventStatusResponse{
+		Success:        true,
+		Message:        fmt.Sprintf("Event %s", status),
+		EventID:        eventID,
+		CompetitionID:  event.CompetitionID,
+		PreviousStatus: event.Status,
+		Status:         status,
+		IsLive:         isLive,
+		UpdatedAt:      now,
+		Sequence:       sequence,
+		Markets:        []models.MarketStatusResponse{},
+	}
+	message := EventStatusMessage{
+		Type:           "event_status",
+		EventID:        eventID,
+		CompetitionID:  event.CompetitionID,
+		PreviousStatus: event.Status,
+		Status:         status,
+		IsLive:         isLive,
+		Reason:         reason,
+		Timestamp:      now,
+		Sequence:       sequence,
+	}
+	if err := enqueueOutbox(tx, EventChannels(event.CompetitionID, eventID, s.publishGlobal), message); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if change, ok := eventMarketTransitions[status]; ok {
+		var marketIDs []uint
+		err := tx.Model(&models.Market{}).
+			Where("event_id = ? AND status IN ?", eventID, change.from).
+			Order("id").
+			Pluck("id", &marketIDs).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to load markets: %v", err)
+		}
+
+		for _, marketID := range marketIDs {
+			marketResponse, err := applyMarketStatus(tx, marketID, change.to, nil, sequence, now)
+			if err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("market %d: %w", marketID, err)
+			}
+			marketResponse.CompetitionID = event.CompetitionID
+
+			channels := CoefficientChannels(event.CompetitionID, eventID, marketID, s.publishGlobal)
+			if err := enqueueOutbox(tx, channels, marketStatusMessage(marketResponse, "event "+status)); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			response.Markets = append(response.Markets, *marketResponse)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit event status: %v", err)
+	}
+
+	return response, nil
+}
+
+// eventStatus returns the status of the event. Called after the event
+// sequence was bumped in tx, the status cannot change before commit.
+func eventStatus(tx *gorm.DB, eventID uint) (string, error) {
+	var event models.Event
+	if err := tx.Select("id", "status").First(&event, eventID).Error; err != nil {
+		return "", fmt.Errorf("failed to load event status: %v", err)
+	}
+	return event.Status, nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FanoutPublisher publishes to several backends concurrently. It fails if any
+// backend fails, so a retry delivers the message again to the backends that
+// already have it.
+type FanoutPublisher struct {
+	publishers map[string]Publisher
+}
+
+func NewFanoutPublisher(publishers map[string]Publisher) *FanoutPublisher {
+	return &FanoutPublisher{
+		publishers: publishers,
+	}
+}
+
+func (p *FanoutPublisher) Publish(ctx context.Context, channels []string, payload []byte) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for name, publisher := range p.publishers {
+		wg.Add(1)
+		go func(name string, publisher Publisher) {
+			defer wg.Done()
+			if err := publisher.Publish(ctx, channels, payload); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %v", name, err))
+				mu.Unlock()
+			}
+		}(name, publisher)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// PublishBatch publishes to every backend, in one call for those that
+// support batches. A publication fails if any backend fails it.
+func (p *FanoutPublisher) PublishBatch(ctx context.Context, publications []Publication) []error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = make([]error, len(publications))
+	)
+	for name, publisher := range p.publishers {
+		wg.Add(1)
+		go func(name string, publisher Publisher) {
+			defer wg.Done()
+			results := publishEach(ctx, publisher, publications)
+
+			mu.Lock()
+			defer mu.Unlock()
+			for i, err := range results {
+				if err != nil {
+					errs[i] = errors.Join(errs[i], fmt.Errorf("%s: %v", name, err))
+				}
+			}
+		}(name, publisher)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// publishEach delivers the publications in one batch when the publisher
+// supports it and one by one otherwise.
+func publishEach(ctx context.Context, publisher Publisher, publications []Publication) []error {
+	if batcher, ok := publisher.(BatchPublisher); ok {
+		return batcher.PublishBatch(ctx, publications)
+	}
+
+	errs := make([]error, len(publications))
+	for i, publication := range publications {
+		errs[i] = publisher.Publish(ctx, publication.Channels, publication.Payload)
+	}
+	return errs
+}
+
+func (p *FanoutPublisher) Close() error {
+	var errs []error
+	for name, publisher := range p.publishers {
+		if err := publisher.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
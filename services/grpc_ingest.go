@@ -0,0 +1,128 @@
+package services
+
+import (
+	"errors"
+	"github.com/VaheMuradyan/Sport/auth"
+	"github.com/VaheMuradyan/Sport/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io"
+	"strconv"
+)
+
+// IngestWindowHeader is the response header of IngestCoefficients with the
+// number of requests the server reads ahead. Feeds may keep that many
+// requests unacknowledged without being blocked.
+const IngestWindowHeader = "ingest-window"
+
+// IngestCoefficients applies a continuous feed of coefficient updates over one
+// stream. Requests are applied one at a time in the order they arrive and each
+// is acknowledged or rejected. A rejected request does not end the stream.
+//
+// Requests are read into a buffer of ingestWindow while the current one is
+// applied. When it is full the server stops reading, and HTTP/2 flow control
+// blocks the feed until acks catch up.
+func (s *GRPCCoefficientServer) IngestCoefficients(stream proto.CoefficientService_IngestCoefficientsServer) error {
+	ctx := stream.Context()
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+
+	if err := stream.SendHeader(metadata.Pairs(IngestWindowHeader, strconv.Itoa(s.ingestWindow))); err != nil {
+		return err
+	}
+
+	requests := make(chan *proto.IngestCoefficientsRequest, s.ingestWindow)
+	recvErr := make(chan error, 1)
+	go func() {
+		defer close(requests)
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				recvErr <- status.FromContextError(ctx.Err()).Err()
+				return
+			}
+		}
+	}()
+
+	for req := range requests {
+		if err := stream.Send(s.ingest(req, identity.UserID)); err != nil {
+			return err
+		}
+	}
+
+	if err := <-recvErr; err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// ingest applies one feed update and builds its ack.
+func (s *GRPCCoefficientServer) ingest(req *proto.IngestCoefficientsRequest, userID uint) *proto.IngestCoefficientsAck {
+	ack := &proto.IngestCoefficientsAck{FeedId: req.FeedId}
+
+	changes, err := s.coefficientChanges(req.Changes)
+	if err != nil {
+		return rejectIngest(ack, err)
+	}
+
+	if len(changes) == 1 {
+		change := changes[0]
+		response, err := s.updater.UpdateCoefficient(change.SelectionID, change.NewCoefficient, userID, change.ExpectedVersion)
+		if err != nil {
+			return rejectIngest(ack, err)
+		}
+		ack.Accepted = true
+		ack.Message = response.Message
+		ack.Sequence = response.Sequence
+		ack.Updates = []*proto.UpdateCoefficientResponse{updateCoefficientResponse(response)}
+		return ack
+	}
+
+	response, err := s.updater.UpdateCoefficients(changes, userID)
+	if err != nil {
+		return rejectIngest(ack, err)
+	}
+	ack.Accepted = true
+	ack.Message = response.Message
+	ack.Sequence = response.Sequence
+	ack.Updates = make([]*proto.UpdateCoefficientResponse, len(response.Updates))
+	for i := range response.Updates {
+		ack.Updates[i] = updateCoefficientResponse(&response.Updates[i])
+	}
+	return ack
+}
+
+func rejectIngest(ack *proto.IngestCoefficientsAck, err error) *proto.IngestCoefficientsAck {
+	ack.Message = err.Error()
+
+	var conflict *VersionConflictError
+	switch {
+	case errors.As(err, &conflict):
+		ack.RejectReason = proto.IngestCoefficientsAck_VERSION_CONFLICT
+		ack.Current = marketCoefficientResponse(conflict.Market)
+	case errors.Is(err, ErrInvalidBatch), errors.Is(err, ErrAmbiguousSelection):
+		ack.RejectReason = proto.IngestCoefficientsAck_INVALID
+	case errors.Is(err, ErrMarketNotFound):
+		ack.RejectReason = proto.IngestCoefficientsAck_MARKET_NOT_FOUND
+	case errors.Is(err, ErrSelectionNotFound):
+		ack.RejectReason = proto.IngestCoefficientsAck_SELECTION_NOT_FOUND
+	case errors.Is(err, ErrCoefficientOutOfBounds):
+		ack.RejectReason = proto.IngestCoefficientsAck_OUT_OF_BOUNDS
+	case errors.Is(err, ErrMarketNotOpen):
+		ack.RejectReason = proto.IngestCoefficientsAck_MARKET_NOT_OPEN
+	case errors.Is(err, ErrPermissionDenied):
+		ack.RejectReason = proto.IngestCoefficientsAck_PERMISSION_DENIED
+	default:
+		ack.RejectReason = proto.IngestCoefficientsAck_INTERNAL
+	}
+	return ack
+}
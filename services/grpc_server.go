@@ -2,29 +2,38 @@ package services
 
 import (
 	"context"
+	"errors"
+	"github.com/VaheMuradyan/Sport/auth"
+	"github.com/VaheMuradyan/Sport/models"
 	"github.com/VaheMuradyan/Sport/proto"
-	"log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type GRPCCoefficientServer struct {
 	proto.UnimplementedCoefficientServiceServer
 	coefficientService *CoefficientService
-	centrifugoService  *CentrifugoService
+	updater            *CoefficientUpdater
+	broker             *CoefficientBroker
+	ingestWindow       int
 }
 
-func NewGRPCCoefficientServer(coefficientService *CoefficientService, centrifugoService *CentrifugoService) *GRPCCoefficientServer {
+func NewGRPCCoefficientServer(coefficientService *CoefficientService, updater *CoefficientUpdater, broker *CoefficientBroker, ingestWindow int) *GRPCCoefficientServer {
 	return &GRPCCoefficientServer{
 		coefficientService: coefficientService,
-		centrifugoService:  centrifugoService,
+		updater:            updater,
+		broker:             broker,
+		ingestWindow:       ingestWindow,
 	}
 }
 
 func (s *GRPCCoefficientServer) UpdateCoefficient(ctx context.Context, req *proto.UpdateCoefficientRequest) (*proto.UpdateCoefficientResponse, error) {
-	response, err := s.coefficientService.UpdateMarketCoefficient(
-		uint(req.MarketId),
-		req.NewCoefficient,
-		uint(req.UserId),
-	)
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+
+	selectionID, err := s.selectionID(req.SelectionId, req.MarketId)
 	if err != nil {
 		return &proto.UpdateCoefficientResponse{
 			Success: false,
@@ -32,30 +41,496 @@ func (s *GRPCCoefficientServer) UpdateCoefficient(ctx context.Context, req *prot
 		}, nil
 	}
 
-	market, err := s.coefficientService.GetMarketWithHistory(uint(req.MarketId))
+	response, err := s.updater.UpdateCoefficient(
+		selectionID,
+		req.NewCoefficient,
+		identity.UserID,
+		req.ExpectedVersion,
+	)
+	if errors.Is(err, ErrPermissionDenied) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	var conflict *VersionConflictError
+	if errors.As(err, &conflict) {
+		st, detailErr := status.New(codes.Aborted, err.Error()).WithDetails(marketCoefficientResponse(conflict.Market))
+		if detailErr != nil {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return nil, st.Err()
+	}
 	if err != nil {
 		return &proto.UpdateCoefficientResponse{
 			Success: false,
-			Message: "Failed to get market details",
+			Message: err.Error(),
 		}, nil
 	}
 
-	err = s.centrifugoService.PublishCoefficientUpdate(
-		market.EventID,
-		uint(req.MarketId),
-		response.OldCoefficient,
-		response.NewCoefficient,
-	)
-	if err != nil {
-		log.Println("cant publish coefficient !!!!!!!!!!!!!!!!!!!!!!!")
-	}
+	return updateCoefficientResponse(response), nil
+}
 
+func updateCoefficientResponse(response *models.CoefficientUpdateResponse) *proto.UpdateCoefficientResponse {
 	return &proto.UpdateCoefficientResponse{
 		Success:        response.Success,
 		Message:        response.Message,
-		MarketId:       req.MarketId,
+		MarketId:       uint32(response.MarketID),
+		SelectionId:    uint32(response.SelectionID),
+		Selection:      response.Selection,
+		Line:           response.Line,
 		OldCoefficient: response.OldCoefficient,
 		NewCoefficient: response.NewCoefficient,
 		UpdatedAt:      response.UpdatedAt.Unix(),
+		Sequence:       response.Sequence,
+		Version:        response.Version,
+	}
+}
+
+// selectionID returns the selection a change addresses. Clients from before
+// selections set only the market, which must then have a single selection.
+func (s *GRPCCoefficientServer) selectionID(selectionID, marketID uint32) (uint, error) {
+	if selectionID != 0 {
+		return uint(selectionID), nil
+	}
+	selection, err := s.coefficientService.MarketSelection(uint(marketID))
+	if err != nil {
+		return 0, err
+	}
+	return selection.ID, nil
+}
+
+func (s *GRPCCoefficientServer) coefficientChanges(changes []*proto.MarketCoefficientChange) ([]CoefficientChange, error) {
+	result := make([]CoefficientChange, len(changes))
+	for i, change := range changes {
+		selectionID, err := s.selectionID(change.SelectionId, change.MarketId)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = CoefficientChange{
+			SelectionID:     selectionID,
+			NewCoefficient:  change.NewCoefficient,
+			ExpectedVersion: change.ExpectedVersion,
+		}
+	}
+	return result, nil
+}
+
+func (s *GRPCCoefficientServer) BatchUpdateCoefficients(ctx context.Context, req *proto.BatchUpdateCoefficientsRequest) (*proto.BatchUpdateCoefficientsResponse, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+
+	changes, err := s.coefficientChanges(req.Changes)
+	if errors.Is(err, ErrAmbiguousSelection) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return &proto.BatchUpdateCoefficientsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	response, err := s.updater.UpdateCoefficients(changes, identity.UserID)
+	if errors.Is(err, ErrInvalidBatch) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, ErrPermissionDenied) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	var conflict *VersionConflictError
+	if errors.As(err, &conflict) {
+		st, detailErr := status.New(codes.Aborted, err.Error()).WithDetails(marketCoefficientResponse(conflict.Market))
+		if detailErr != nil {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return nil, st.Err()
+	}
+	if err != nil {
+		return &proto.BatchUpdateCoefficientsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	updates := make([]*proto.UpdateCoefficientResponse, len(response.Updates))
+	for i := range response.Updates {
+		updates[i] = updateCoefficientResponse(&response.Updates[i])
+	}
+
+	return &proto.BatchUpdateCoefficientsResponse{
+		Success:   response.Success,
+		Message:   response.Message,
+		EventId:   uint32(response.EventID),
+		Sequence:  response.Sequence,
+		UpdatedAt: response.UpdatedAt.Unix(),
+		Updates:   updates,
+	}, nil
+}
+
+func (s *GRPCCoefficientServer) GetMarketCoefficient(ctx context.Context, req *proto.GetMarketCoefficientRequest) (*proto.GetMarketCoefficientResponse, error) {
+	if req.MarketId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "market_id is required")
+	}
+
+	market, err := s.coefficientService.GetMarket(uint(req.MarketId))
+	if err != nil {
+		if errors.Is(err, ErrMarketNotFound) {
+			return nil, status.Errorf(codes.NotFound, "market %d not found", req.MarketId)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return marketCoefficientResponse(market), nil
+}
+
+func marketCoefficientResponse(market *models.Market) *proto.GetMarketCoefficientResponse {
+	response := &proto.GetMarketCoefficientResponse{
+		MarketId:   uint32(market.ID),
+		Active:     market.Status == models.MarketOpen,
+		Version:    market.Version,
+		Status:     market.Status,
+		Selections: make([]*proto.Selection, len(market.Selections)),
+		Type:       market.Type,
+		Line:       market.Line,
+		Period:     market.Period,
+		Player:     market.Player,
+		TeamId:     optionalID(market.TeamID),
+	}
+	for i := range market.Selections {
+		response.Selections[i] = selectionProto(&market.Selections[i])
+	}
+	if len(market.Selections) == 1 {
+		only := market.Selections[0]
+		response.CurrentCoefficient = only.CurrentCoefficient
+		response.PreviousCoefficient = only.PreviousCoefficient
+		response.LastUpdated = only.LastUpdated.Unix()
+	}
+	return response
+}
+
+func optionalID(id *uint) *uint32 {
+	if id == nil {
+		return nil
+	}
+	value := uint32(*id)
+	return &value
+}
+
+func selectionProto(selection *models.Selection) *proto.Selection {
+	return &proto.Selection{
+		SelectionId:         uint32(selection.ID),
+		MarketId:            uint32(selection.MarketID),
+		Name:                selection.Name,
+		CurrentCoefficient:  selection.CurrentCoefficient,
+		PreviousCoefficient: selection.PreviousCoefficient,
+		LastUpdated:         selection.LastUpdated.Unix(),
+		Version:             selection.Version,
+		MinCoefficient:      selection.MinCoefficient,
+		MaxCoefficient:      selection.MaxCoefficient,
+	}
+}
+
+func (s *GRPCCoefficientServer) SetMarketStatus(ctx context.Context, req *proto.SetMarketStatusRequest) (*proto.SetMarketStatusResponse, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+	if req.MarketId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "market_id is required")
+	}
+
+	response, err := s.updater.SetMarketStatus(uint(req.MarketId), req.Status, req.Reason, identity.UserID, req.ExpectedVersion)
+	if err != nil {
+		var conflict *VersionConflictError
+		switch {
+		case errors.As(err, &conflict):
+			st, detailErr := status.New(codes.Aborted, err.Error()).WithDetails(marketCoefficientResponse(conflict.Market))
+			if detailErr != nil {
+				return nil, status.Error(codes.Aborted, err.Error())
+			}
+			return nil, st.Err()
+		case errors.Is(err, ErrMarketNotFound):
+			return nil, status.Errorf(codes.NotFound, "market %d not found", req.MarketId)
+		case errors.Is(err, ErrPermissionDenied):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, ErrInvalidTransition):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return marketStatusResponse(response), nil
+}
+
+func (s *GRPCCoefficientServer) MoveMarketLine(ctx context.Context, req *proto.MoveMarketLineRequest) (*proto.MoveMarketLineResponse, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+	if req.MarketId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "market_id is required")
+	}
+
+	changes := make([]CoefficientChange, len(req.Coefficients))
+	for i, change := range req.Coefficients {
+		if change.SelectionId == 0 {
+			return nil, status.Error(codes.InvalidArgument, "selection_id is required for the coefficients of a line move")
+		}
+		changes[i] = CoefficientChange{
+			SelectionID:     uint(change.SelectionId),
+			NewCoefficient:  change.NewCoefficient,
+			ExpectedVersion: change.ExpectedVersion,
+		}
+	}
+
+	response, err := s.updater.MoveMarketLine(uint(req.MarketId), req.Line, changes, identity.UserID, req.ExpectedVersion)
+	if err != nil {
+		var conflict *VersionConflictError
+		switch {
+		case errors.As(err, &conflict):
+			st, detailErr := status.New(codes.Aborted, err.Error()).WithDetails(marketCoefficientResponse(conflict.Market))
+			if detailErr != nil {
+				return nil, status.Error(codes.Aborted, err.Error())
+			}
+			return nil, st.Err()
+		case errors.Is(err, ErrMarketNotFound):
+			return nil, status.Errorf(codes.NotFound, "market %d not found", req.MarketId)
+		case errors.Is(err, ErrPermissionDenied):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, ErrInvalidMarketParams), errors.Is(err, ErrInvalidBatch), errors.Is(err, ErrCoefficientOutOfBounds):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, ErrMarketNotOpen):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	updates := make([]*proto.UpdateCoefficientResponse, len(response.Updates))
+	for i := range response.Updates {
+		updates[i] = updateCoefficientResponse(&response.Updates[i])
+	}
+
+	return &proto.MoveMarketLineResponse{
+		Success:      response.Success,
+		Message:      response.Message,
+		MarketId:     uint32(response.MarketID),
+		PreviousLine: response.PreviousLine,
+		Line:         response.Line,
+		UpdatedAt:    response.UpdatedAt.Unix(),
+		Sequence:     response.Sequence,
+		Version:      response.Version,
+		Updates:      updates,
+	}, nil
+}
+
+func marketStatusResponse(response *models.MarketStatusResponse) *proto.SetMarketStatusResponse {
+	return &proto.SetMarketStatusResponse{
+		Success:        response.Success,
+		Message:        response.Message,
+		MarketId:       uint32(response.MarketID),
+		PreviousStatus: response.PreviousStatus,
+		Status:         response.Status,
+		UpdatedAt:      response.UpdatedAt.Unix(),
+		Sequence:       response.Sequence,
+		Version:        response.Version,
+	}
+}
+
+func (s *GRPCCoefficientServer) SetEventStatus(ctx context.Context, req *proto.SetEventStatusRequest) (*proto.SetEventStatusResponse, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+	if req.EventId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id is required")
+	}
+
+	response, err := s.updater.SetEventStatus(uint(req.EventId), req.Status, req.Reason, identity.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrEventNotFound):
+			return nil, status.Errorf(codes.NotFound, "event %d not found", req.EventId)
+		case errors.Is(err, ErrPermissionDenied):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, ErrInvalidTransition):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	markets := make([]*proto.SetMarketStatusResponse, len(response.Markets))
+	for i := range response.Markets {
+		markets[i] = marketStatusResponse(&response.Markets[i])
+	}
+
+	return &proto.SetEventStatusResponse{
+		Success:        response.Success,
+		Message:        response.Message,
+		EventId:        uint32(response.EventID),
+		PreviousStatus: response.PreviousStatus,
+		Status:         response.Status,
+		IsLive:         response.IsLive,
+		UpdatedAt:      response.UpdatedAt.Unix(),
+		Sequence:       response.Sequence,
+		Markets:        markets,
+	}, nil
+}
+
+func (s *GRPCCoefficientServer) RecordIncident(ctx context.Context, req *proto.RecordIncidentRequest) (*proto.RecordIncidentResponse, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+	if req.EventId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id is required")
+	}
+
+	incident := models.IncidentRequest{
+		Type:   req.Type,
+		Period: req.Period,
+		Minute: int(req.Minute),
+		Player: req.Player,
+		Detail: req.Detail,
+	}
+	if req.TeamId != nil {
+		teamID := uint(*req.TeamId)
+		incident.TeamID = &teamID
+	}
+
+	message, err := s.updater.RecordIncident(uint(req.EventId), incident, identity.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrEventNotFound):
+			return nil, status.Errorf(codes.NotFound, "event %d not found", req.EventId)
+		case errors.Is(err, ErrPermissionDenied):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, ErrInvalidIncident):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, ErrEventNotLive):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.RecordIncidentResponse{
+		Success:    true,
+		Message:    "Incident recorded",
+		EventId:    uint32(message.EventID),
+		Incident:   incidentProto(message),
+		RecordedAt: message.Timestamp.Unix(),
+		Sequence:   message.Sequence,
+	}, nil
+}
+
+func incidentProto(message *IncidentMessage) *proto.Incident {
+	score := make([]*proto.TeamScore, len(message.Score))
+	for i, team := range message.Score {
+		periods := make(map[string]int32, len(team.Periods))
+		for period, goals := range team.Periods {
+			periods[period] = int32(goals)
+		}
+		score[i] = &proto.TeamScore{
+			TeamId:  uint32(team.TeamID),
+			Name:    team.Name,
+			Goals:   int32(team.Goals),
+			Periods: periods,
+		}
+	}
+
+	incident := &proto.Incident{
+		Id:       uint32(message.IncidentID),
+		Type:     message.IncidentType,
+		TeamName: message.TeamName,
+		Period:   message.Period,
+		Minute:   uint32(message.Minute),
+		Player:   message.Player,
+		Detail:   message.Detail,
+		Score:    score,
+	}
+	if message.TeamID != nil {
+		incident.TeamId = uint32(*message.TeamID)
+	}
+	return incident
+}
+
+func (s *GRPCCoefficientServer) StreamCoefficientUpdates(req *proto.StreamCoefficientRequest, stream proto.CoefficientService_StreamCoefficientUpdatesServer) error {
+	sub := s.broker.Subscribe(req.EventIds, req.MarketIds)
+	defer s.broker.Unsubscribe(sub)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event, ok := <-sub.Updates():
+			if !ok {
+				if sub.Overflowed() {
+					return status.Error(codes.ResourceExhausted, "subscriber is too slow, update buffer overflowed")
+				}
+				return status.Error(codes.Unavailable, "coefficient stream closed")
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *GRPCCoefficientServer) GetEventSnapshot(ctx context.Context, req *proto.GetEventSnapshotRequest) (*proto.EventSnapshot, error) {
+	if req.EventId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id is required")
+	}
+
+	snapshot, err := s.coefficientService.GetEventSnapshot(uint(req.EventId))
+	if err != nil {
+		if errors.Is(err, ErrEventNotFound) {
+			return nil, status.Errorf(codes.NotFound, "event %d not found", req.EventId)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	markets := make([]*proto.MarketSnapshot, len(snapshot.Markets))
+	for i, market := range snapshot.Markets {
+		selections := make([]*proto.Selection, len(market.Selections))
+		for j, selection := range market.Selections {
+			selections[j] = &proto.Selection{
+				SelectionId:         uint32(selection.SelectionID),
+				MarketId:            uint32(market.MarketID),
+				Name:                selection.Name,
+				CurrentCoefficient:  selection.CurrentCoefficient,
+				PreviousCoefficient: selection.PreviousCoefficient,
+				LastUpdated:         selection.LastUpdated.Unix(),
+			}
+		}
+		markets[i] = &proto.MarketSnapshot{
+			MarketId:   uint32(market.MarketID),
+			Name:       market.Name,
+			Type:       market.Type,
+			Status:     market.Status,
+			Selections: selections,
+			Line:       market.Line,
+			Period:     market.Period,
+			Player:     market.Player,
+			TeamId:     optionalID(market.TeamID),
+		}
+		if len(selections) == 1 {
+			markets[i].CurrentCoefficient = selections[0].CurrentCoefficient
+			markets[i].PreviousCoefficient = selections[0].PreviousCoefficient
+			markets[i].LastUpdated = selections[0].LastUpdated
+		}
+	}
+
+	return &proto.EventSnapshot{
+		EventId:       uint32(snapshot.EventID),
+		CompetitionId: uint32(snapshot.CompetitionID),
+		Sequence:      snapshot.Sequence,
+		Markets:       markets,
+		Timestamp:     snapshot.Timestamp.Unix(),
+		Status:        snapshot.Status,
+		IsLive:        snapshot.IsLive,
 	}, nil
 }
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"gorm.io/gorm"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidIncident = errors.New("invalid incident")
+	ErrEventNotLive    = errors.New("event is not live")
+)
+
+var (
+	teamIncidents   = []string{models.IncidentGoal, models.IncidentCard, models.IncidentPenalty, models.IncidentCorner}
+	periodIncidents = []string{models.IncidentPeriodStart, models.IncidentPeriodEnd}
+	periods         = []string{models.PeriodFirstHalf, models.PeriodSecondHalf, models.PeriodExtraTime, models.PeriodPenalties}
+)
+
+// TeamScore is the score of a team in an event, in total and per period.
+type TeamScore struct {
+	TeamID  uint           `json:"team_id"`
+	Name    string         `json:"name"`
+	Goals   int            `json:"goals"`
+	Periods map[string]int `json:"periods,omitempty"`
+}
+
+// IncidentMessage announces an incident on the event channels. It carries the
+// score after the incident.
+type IncidentMessage struct {
+	Type          string      `json:"type"`
+	EventID       uint        `json:"event_id"`
+	CompetitionID uint        `json:"competition_id"`
+	IncidentID    uint        `json:"incident_id"`
+	IncidentType  string      `json:"incident_type"`
+	TeamID        *uint       `json:"team_id,omitempty"`
+	TeamName      string      `json:"team_name,omitempty"`
+	Period        string      `json:"period"`
+	Minute        int         `json:"minute"`
+	Player        string      `json:"player,omitempty"`
+	Detail        string      `json:"detail,omitempty"`
+	Score         []TeamScore `json:"score"`
+	Timestamp     time.Time   `json:"timestamp"`
+	Sequence      uint64      `json:"sequence"` // per event, see EventSnapshot
+}
+
+// Scoreboard is the state of play of an event.
+type Scoreboard struct {
+	EventID uint                 `json:"event_id"`
+	Status  string               `json:"status"`
+	IsLive  bool                 `json:"is_live"`
+	Period  string               `json:"period,omitempty"` // the period in play
+	Periods []models.EventPeriod `json:"periods"`
+	Score   []TeamScore          `json:"score"`
+}
+
+// RecordIncident stores an incident of a live event, updates the score on
+// goals and the periods on period incidents, and publishes it.
+func (s *CoefficientService) RecordIncident(eventID uint, req models.IncidentRequest, userID uint) (*IncidentMessage, error) {
+	if err := validateIncident(req); err != nil {
+		return nil, err
+	}
+
+	var event models.Event
+	if err := s.db.Select("id", "competition_id").First(&event, eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to load event: %v", err)
+	}
+	if err := s.permissions.CanRecordIncident(userID, &event); err != nil {
+		return nil, err
+	}
+
+	tx := s.db.Begin()
+
+	sequence, err := nextEventSequence(tx, eventID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	status, err := eventStatus(tx, eventID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if status != models.EventLive && status != models.EventHalfTime {
+		tx.Rollback()
+		return nil, fmt.Errorf("%w: event %d is %s", ErrEventNotLive, eventID, status)
+	}
+
+	var team models.Team
+	if req.TeamID != nil {
+		err := tx.Joins("JOIN event_teams ON event_teams.team_id = teams.id").
+			Where("event_teams.event_id = ? AND teams.id = ?", eventID, *req.TeamID).
+			First(&team).Error
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: team %d does not play in event %d", ErrInvalidIncident, *req.TeamID, eventID)
+			}
+			return nil, fmt.Errorf("failed to load team: %v", err)
+		}
+	}
+
+	now := time.Now()
+	if err := applyIncident(tx, eventID, req, now); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	incident := models.Incident{
+		EventID:      eventID,
+		Type:         req.Type,
+		TeamID:       req.TeamID,
+		Period:       req.Period,
+		Minute:       req.Minute,
+		Player:       req.Player,
+		Detail:       req.Detail,
+		RecordedByID: userID,
+		Timestamp:    now,
+		Sequence:     sequence,
+	}
+	if err := tx.Create(&incident).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to record incident: %v", err)
+	}
+
+	score, err := eventScore(tx, eventID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	message := &IncidentMessage{
+		Type:          "incident",
+		EventID:       eventID,
+		CompetitionID: event.CompetitionID,
+		IncidentID:    incident.ID,
+		IncidentType:  incident.Type,
+		TeamID:        incident.TeamID,
+		TeamName:      team.Name,
+		Period:        incident.Period,
+		Minute:        incident.Minute,
+		Player:        incident.Player,
+		Detail:        incident.Detail,
+		Score:         score,
+		Timestamp:     now,
+		Sequence:      sequence,
+	}
+	if err := enqueueOutbox(tx, EventChannels(event.CompetitionID, eventID, s.publishGlobal), message); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit incident: %v", err)
+	}
+
+	return message, nil
+}
+
+func validateIncident(req models.IncidentRequest) error {
+	if !slices.Contains(periods, req.Period) {
+		return fmt.Errorf("%w: unknown period %q", ErrInvalidIncident, req.Period)
+	}
+	if req.Minute < 0 {
+		return fmt.Errorf("%w: minute must not be negative", ErrInvalidIncident)
+	}
+
+	switch {
+	case slices.Contains(teamIncidents, req.Type):
+		if req.TeamID == nil {
+			return fmt.Errorf("%w: %s requires team_id", ErrInvalidIncident, req.Type)
+		}
+	case slices.Contains(periodIncidents, req.Type):
+		if req.TeamID != nil {
+			return fmt.Errorf("%w: %s has no team", ErrInvalidIncident, req.Type)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidIncident, req.Type)
+	}
+
+	if req.Type == models.IncidentCard && req.Detail != "yellow" && req.Detail != "red" {
+		return fmt.Errorf("%w: card detail must be yellow or red", ErrInvalidIncident)
+	}
+	return nil
+}
+
+// applyIncident updates the periods and the score of the event for the
+// incident in tx.
+func applyIncident(tx *gorm.DB, eventID uint, req models.IncidentRequest, now time.Time) error {
+	switch req.Type {
+	case models.IncidentPeriodStart:
+		var count int64
+		if err := tx.Model(&models.EventPeriod{}).Where("event_id = ? AND period = ?", eventID, req.Period).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to load periods: %v", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %s already started", ErrInvalidIncident, req.Period)
+		}
+		if err := tx.Model(&models.EventPeriod{}).Where("event_id = ? AND ended_at IS NULL", eventID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to load periods: %v", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: the current period has not ended", ErrInvalidIncident)
+		}
+		period := models.EventPeriod{EventID: eventID, Period: req.Period, StartedAt: now}
+		if err := tx.Create(&period).Error; err != nil {
+			return fmt.Errorf("failed to start period: %v", err)
+		}
+
+	case models.IncidentPeriodEnd:
+		result := tx.Model(&models.EventPeriod{}).
+			Where("event_id = ? AND period = ? AND ended_at IS NULL", eventID, req.Period).
+			Update("ended_at", now)
+		if result.Error != nil {
+			return fmt.Errorf("failed to end period: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: %s is not in play", ErrInvalidIncident, req.Period)
+		}
+
+	default:
+		var count int64
+		if err := tx.Model(&models.EventPeriod{}).Where("event_id = ? AND period = ? AND ended_at IS NULL", eventID, req.Period).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to load periods: %v", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("%w: %s is not in play", ErrInvalidIncident, req.Period)
+		}
+	}
+
+	if req.Type == models.IncidentGoal {
+		score := models.Score{EventID: eventID, TeamID: *req.TeamID, Period: req.Period}
+		if err := tx.Where(&score).FirstOrCreate(&score).Error; err != nil {
+			return fmt.Errorf("failed to load score: %v", err)
+		}
+		if err := tx.Model(&score).UpdateColumn("goals", gorm.Expr("goals + 1")).Error; err != nil {
+			return fmt.Errorf("failed to update score: %v", err)
+		}
+	}
+	return nil
+}
+
+// eventScore returns the score of every team of the event.
+func eventScore(db *gorm.DB, eventID uint) ([]TeamScore, error) {
+	var teams []models.Team
+	err := db.Joins("JOIN event_teams ON event_teams.team_id = teams.id").
+		Where("event_teams.event_id = ?", eventID).
+		Order("teams.id").
+		Find(&teams).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load teams: %v", err)
+	}
+
+	var scores []models.Score
+	if err := db.Where("event_id = ?", eventID).Find(&scores).Error; err != nil {
+		return nil, fmt.Errorf("failed to load scores: %v", err)
+	}
+
+	result := make([]TeamScore, len(teams))
+	for i, team := range teams {
+		result[i] = TeamScore{TeamID: team.ID, Name: team.Name, Periods: map[string]int{}}
+		for _, score := range scores {
+			if score.TeamID == team.ID {
+				result[i].Goals += score.Goals
+				result[i].Periods[score.Period] += score.Goals
+			}
+		}
+	}
+	return result, nil
+}
+
+// ListIncidents returns the incidents of the event in the order they were
+// recorded.
+func (s *CoefficientService) ListIncidents(eventID uint) ([]models.Incident, error) {
+	if err := s.db.Select("id").First(&models.Event{}, eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to load event: %v", err)
+	}
+
+	incidents := []models.Incident{}
+	if err := s.db.Preload("Team").Where("event_id = ?", eventID).Order("sequence, id").Find(&incidents).Error; err != nil {
+		return nil, fmt.Errorf("failed to load incidents: %v", err)
+	}
+	return incidents, nil
+}
+
+// GetScoreboard returns the status, periods and score of the event.
+func (s *CoefficientService) GetScoreboard(eventID uint) (*Scoreboard, error) {
+	var event models.Event
+	if err := s.db.Select("id", "status", "is_live").First(&event, eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to load event: %v", err)
+	}
+
+	board := &Scoreboard{
+		EventID: event.ID,
+		Status:  event.Status,
+		IsLive:  event.IsLive,
+		Periods: []models.EventPeriod{},
+	}
+	if err := s.db.Where("event_id = ?", eventID).Order("started_at, id").Find(&board.Periods).Error; err != nil {
+		return nil, fmt.Errorf("failed to load periods: %v", err)
+	}
+	for _, period := range board.Periods {
+		if period.EndedAt == nil {
+			board.Period = period.Period
+		}
+	}
+
+	score, err := eventScore(s.db, eventID)
+	if err != nil {
+		return nil, err
+	}
+	board.Score = score
+	return board, nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"strings"
+)
+
+// KafkaPublisher writes one record per publication to a single topic. The
+// record is keyed by the first channel, the market channel for coefficient
+// updates, so the updates of a market stay ordered within a partition. The
+// full channel list is carried in the "channels" header.
+type KafkaPublisher struct {
+	writer kafkaWriter
+}
+
+// kafkaWriter is the part of kafka.Writer the publisher uses.
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+func NewKafkaPublisher(brokers []string, topic string) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, channels []string, payload []byte) error {
+	message := kafka.Message{
+		Value: payload,
+		Headers: []kafka.Header{
+			{Key: "channels", Value: []byte(strings.Join(channels, ","))},
+		},
+	}
+	if len(channels) > 0 {
+		message.Key = []byte(channels[0])
+	}
+
+	if err := p.writer.WriteMessages(ctx, message); err != nil {
+		return fmt.Errorf("failed to write to kafka: %v", err)
+	}
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
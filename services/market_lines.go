@@ -0,0 +1,319 @@
+package services
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"math"
+	"slices"
+	"time"
+)
+
+var ErrInvalidMarketParams = errors.New("invalid market parameters")
+
+// marketParams lists the parameters a market type takes. A market must set
+// every parameter of its type and no other; any market may be limited to a
+// period.
+type marketParams struct {
+	line         bool
+	negativeLine bool
+	team         bool
+	player       bool
+}
+
+var marketTypes = map[string]marketParams{
+	models.MarketMatchWinner: {},
+	models.MarketOverUnder:   {line: true},
+	models.MarketHandicap:    {line: true, negativeLine: true, team: true},
+	models.MarketTeamTotal:   {line: true, team: true},
+	models.MarketPlayerGoals: {line: true, player: true},
+	models.MarketBothToScore: {},
+}
+
+// marketParamsOf returns the parameters of a market type. Markets without a
+// type take none; unknown types are rejected.
+func marketParamsOf(marketType string) (marketParams, error) {
+	if marketType == "" {
+		return marketParams{}, nil
+	}
+	params, ok := marketTypes[marketType]
+	if !ok {
+		return marketParams{}, fmt.Errorf("%w: unknown market type %q", ErrInvalidMarketParams, marketType)
+	}
+	return params, nil
+}
+
+// lineStep is the granularity of lines, which allows the quarter lines of
+// Asian handicaps and totals.
+const lineStep = 0.25
+
+// ValidateMarket checks the parameters of a market against its type. The team
+// of a market must play in its event.
+func ValidateMarket(db *gorm.DB, market *models.Market) error {
+	params, err := marketParamsOf(market.Type)
+	if err != nil {
+		return err
+	}
+	if err := validateLineAndPeriod(market.Type, market.Line, market.Period); err != nil {
+		return err
+	}
+
+	if market.TeamID == nil && params.team {
+		return fmt.Errorf("%w: %s markets need a team", ErrInvalidMarketParams, market.Type)
+	}
+	if market.TeamID != nil {
+		if !params.team {
+			return fmt.Errorf("%w: %q markets take no team", ErrInvalidMarketParams, market.Type)
+		}
+		var count int64
+		err := db.Table("event_teams").
+			Where("event_id = ? AND team_id = ?", market.EventID, *market.TeamID).
+			Count(&count).Error
+		if err != nil {
+			return fmt.Errorf("failed to check event teams: %v", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("%w: team %d does not play in event %d", ErrInvalidMarketParams, *market.TeamID, market.EventID)
+		}
+	}
+
+	if market.Player == "" && params.player {
+		return fmt.Errorf("%w: %s markets need a player", ErrInvalidMarketParams, market.Type)
+	}
+	if market.Player != "" && !params.player {
+		return fmt.Errorf("%w: %q markets take no player", ErrInvalidMarketParams, market.Type)
+	}
+	return nil
+}
+
+// validateLineAndPeriod checks the parameters that markets and market
+// templates share.
+func validateLineAndPeriod(marketType string, line *float64, period string) error {
+	params, err := marketParamsOf(marketType)
+	if err != nil {
+		return err
+	}
+	if line == nil && params.line {
+		return fmt.Errorf("%w: %s markets need a line", ErrInvalidMarketParams, marketType)
+	}
+	if line != nil {
+		if !params.line {
+			return fmt.Errorf("%w: %q markets take no line", ErrInvalidMarketParams, marketType)
+		}
+		if err := validateLine(marketType, *line); err != nil {
+			return err
+		}
+	}
+
+	if period != "" && !slices.Contains(periods, period) {
+		return fmt.Errorf("%w: unknown period %q", ErrInvalidMarketParams, period)
+	}
+	return nil
+}
+
+func validateLine(marketType string, line float64) error {
+	if steps := line / lineStep; steps != math.Trunc(steps) {
+		return fmt.Errorf("%w: line %.2f is not a multiple of %.2f", ErrInvalidMarketParams, line, lineStep)
+	}
+	if line <= 0 && !marketTypes[marketType].negativeLine {
+		return fmt.Errorf("%w: line of %s markets must be positive, got %.2f", ErrInvalidMarketParams, marketType, line)
+	}
+	return nil
+}
+
+// MarketLineMessage announces that the line of a market moved, with the odds
+// quoted at the new line. Front-ends replace the line and prices of the market
+// at once.
+type MarketLineMessage struct {
+	Type          string                     `json:"type"`
+	MarketID      uint                       `json:"market_id"`
+	EventID       uint                       `json:"event_id"`
+	CompetitionID uint                       `json:"competition_id"`
+	PreviousLine  *float64                   `json:"previous_line"`
+	Line          float64                    `json:"line"`
+	Updates       []CoefficientUpdateMessage `json:"updates"`
+	Timestamp     time.Time                  `json:"timestamp"`
+	Sequence      uint64                     `json:"sequence"` // per event, see EventSnapshot
+}
+
+// MoveMarketLine moves the line of the market, or sets the first line of a
+// market from before lines, and sets the odds of its selections quoted at the
+// new line, all or nothing. The move is recorded in the line history and
+// published as a market_line message. With expectedVersion set it is only
+// applied if the market is still at that version.
+func (s *CoefficientService) MoveMarketLine(marketID uint, line float64, changes []CoefficientChange, userID uint, expectedVersion *uint64) (*models.MarketLineResponse, error) {
+	market, err := s.GetMarket(marketID)
+	if err != nil {
+		return nil, err
+	}
+	if !marketTypes[market.Type].line {
+		return nil, fmt.Errorf("%w: %q markets take no line", ErrInvalidMarketParams, market.Type)
+	}
+	if err := validateLine(market.Type, line); err != nil {
+		return nil, err
+	}
+	if err := s.permissions.CanTradeMarket(userID, market); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint]bool, len(changes))
+	for _, change := range changes {
+		if seen[change.SelectionID] {
+			return nil, fmt.Errorf("%w: selection %d is changed more than once", ErrInvalidBatch, change.SelectionID)
+		}
+		seen[change.SelectionID] = true
+
+		index := slices.IndexFunc(market.Selections, func(selection models.Selection) bool {
+			return selection.ID == change.SelectionID
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("%w: selection %d is not part of market %d", ErrInvalidBatch, change.SelectionID, marketID)
+		}
+		selection := market.Selections[index]
+		selection.Market = market
+		if err := s.checkChange(&selection, change.NewCoefficient, userID); err != nil {
+			return nil, fmt.Errorf("selection %d: %w", selection.ID, err)
+		}
+	}
+
+	var event models.Event
+	if err := s.db.Select("id", "competition_id").First(&event, market.EventID).Error; err != nil {
+		return nil, fmt.Errorf("failed to load event %d: %v", market.EventID, err)
+	}
+
+	tx := s.db.Begin()
+
+	sequence, err := nextEventSequence(tx, market.EventID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	now := time.Now()
+	response, err := applyMarketLine(tx, marketID, line, userID, expectedVersion, sequence, now)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	response.CompetitionID = event.CompetitionID
+
+	// Selections are locked in ID order, as in UpdateSelectionCoefficients.
+	ordered := slices.Clone(changes)
+	slices.SortFunc(ordered, func(a, b CoefficientChange) int {
+		return cmp.Compare(a.SelectionID, b.SelectionID)
+	})
+
+	message := MarketLineMessage{
+		Type:          "market_line",
+		MarketID:      marketID,
+		EventID:       market.EventID,
+		CompetitionID: event.CompetitionID,
+		PreviousLine:  response.PreviousLine,
+		Line:          line,
+		Updates:       make([]CoefficientUpdateMessage, 0, len(ordered)),
+		Timestamp:     now,
+		Sequence:      sequence,
+	}
+	for _, change := range ordered {
+		update, err := s.applyChange(tx, change, userID, sequence, now)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("selection %d: %w", change.SelectionID, err)
+		}
+		update.CompetitionID = event.CompetitionID
+
+		response.Updates = append(response.Updates, *update)
+		message.Updates = append(message.Updates, coefficientUpdateMessage(update))
+	}
+
+	channels := CoefficientChannels(event.CompetitionID, market.EventID, marketID, s.publishGlobal)
+	if err := enqueueOutbox(tx, channels, message); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit market line: %v", err)
+	}
+
+	return response, nil
+}
+
+// applyMarketLine writes the new line of a market and its history entry in
+// tx. The event sequence must already have been bumped in tx.
+func applyMarketLine(tx *gorm.DB, marketID uint, line float64, userID uint, expectedVersion *uint64, sequence uint64, now time.Time) (*models.MarketLineResponse, error) {
+	var market models.Market
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&market, marketID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMarketNotFound
+		}
+		return nil, fmt.Errorf("failed to reload market: %v", err)
+	}
+	if expectedVersion != nil && market.Version != *expectedVersion {
+		current, err := loadMarket(tx, marketID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &VersionConflictError{ExpectedVersion: *expectedVersion, Market: current}
+	}
+	if market.Status != models.MarketOpen {
+		return nil, fmt.Errorf("%w: market %d is %s", ErrMarketNotOpen, marketID, market.Status)
+	}
+	version := market.Version
+	result := tx.Model(&models.Market{}).
+		Where("id = ? AND version = ?", marketID, version).
+		Updates(map[string]interface{}{
+			"line":    line,
+			"version": version + 1,
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update market line: %v", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		current, err := loadMarket(tx, marketID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &VersionConflictError{ExpectedVersion: version, Market: current}
+	}
+
+	lineHistory := models.LineHistory{
+		MarketID:    marketID,
+		OldLine:     market.Line,
+		NewLine:     line,
+		ChangedByID: userID,
+		Timestamp:   now,
+		Sequence:    sequence,
+	}
+	if err := tx.Create(&lineHistory).Error; err != nil {
+		return nil, fmt.Errorf("failed to record line history: %v", err)
+	}
+
+	return &models.MarketLineResponse{
+		Success:      true,
+		Message:      "Market line moved",
+		MarketID:     marketID,
+		EventID:      market.EventID,
+		PreviousLine: market.Line,
+		Line:         line,
+		Updates:      []models.CoefficientUpdateResponse{},
+		UpdatedAt:    now,
+		Sequence:     sequence,
+		Version:      version + 1,
+	}, nil
+}
+
+// GetLineHistory returns the line moves of the market, oldest first.
+func (s *CoefficientService) GetLineHistory(marketID uint) ([]models.LineHistory, error) {
+	if _, err := s.GetMarket(marketID); err != nil {
+		return nil, err
+	}
+	var history []models.LineHistory
+	if err := s.db.Where("market_id = ?", marketID).Order("id").Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("failed to load line history: %v", err)
+	}
+	return history, nil
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
+	"time"
+)
+
+var (
+	ErrMarketNotOpen     = errors.New("market is not open")
+	ErrInvalidTransition = errors.New("invalid status transition")
+)
+
+// marketTransitions lists the statuses a market may move to from each status.
+// Settled and voided markets are final.
+var marketTransitions = map[string][]string{
+	models.MarketOpen:      {models.MarketSuspended, models.MarketClosed, models.MarketVoided},
+	models.MarketSuspended: {models.MarketOpen, models.MarketClosed, models.MarketVoided},
+	models.MarketClosed:    {models.MarketSettled, models.MarketVoided},
+	models.MarketSettled:   {},
+	models.MarketVoided:    {},
+}
+
+// CanTransitionMarket reports whether a market may move from one status to
+// another.
+func CanTransitionMarket(from, to string) bool {
+	return slices.Contains(marketTransitions[from], to)
+}
+
+// MarketStatusMessage announces that a market changed status. Front-ends
+// disable the market unless it is open.
+type MarketStatusMessage struct {
+	Type           string    `json:"type"`
+	MarketID       uint      `json:"market_id"`
+	EventID        uint      `json:"event_id"`
+	CompetitionID  uint      `json:"competition_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	Reason         string    `json:"reason,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+	Sequence       uint64    `json:"sequence"` // per event, see EventSnapshot
+}
+
+// SetMarketStatus moves the market to status and publishes a market_status
+// message on its channels. With expectedVersion set the change is only
+// applied if the market is still at that version.
+func (s *CoefficientService) SetMarketStatus(marketID uint, status, reason string, userID uint, expectedVersion *uint64) (*models.MarketStatusResponse, error) {
+	if _, ok := marketTransitions[status]; !ok {
+		return nil, fmt.Errorf("%w: unknown market status %q", ErrInvalidTransition, status)
+	}
+
+	market, err := s.GetMarket(marketID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.permissions.CanChangeMarketStatus(userID, market); err != nil {
+		return nil, err
+	}
+
+	var event models.Event
+	if err := s.db.Select("id", "competition_id").First(&event, market.EventID).Error; err != nil {
+		return nil, fmt.Errorf("failed to load event %d: %v", market.EventID, err)
+	}
+
+	tx := s.db.Begin()
+
+	sequence, err := nextEventSequence(tx, market.EventID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if status == models.MarketOpen {
+		current, err := eventStatus(tx, market.EventID)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if !EventAllowsTrading(current) {
+			tx.Rollback()
+			return nil, fmt.Errorf("%w: market %d cannot open, event %d is %s", ErrInvalidTransition, marketID, market.EventID, current)
+		}
+	}
+
+	response, err := applyMarketStatus(tx, marketID, status, expectedVersion, sequence, time.Now())
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	response.CompetitionID = event.CompetitionID
+
+	channels := CoefficientChannels(event.CompetitionID, market.EventID, marketID, s.publishGlobal)
+	if err := enqueueOutbox(tx, channels, marketStatusMessage(response, reason)); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit market status: %v", err)
+	}
+
+	return response, nil
+}
+
+// applyMarketStatus writes the new status of a market in tx. The event
+// sequence must already have been bumped in tx.
+func applyMarketStatus(tx *gorm.DB, marketID uint, status string, expectedVersion *uint64, sequence uint64, now time.Time) (*models.MarketStatusResponse, error) {
+	var market models.Market
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&market, marketID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMarketNotFound
+		}
+		return nil, fmt.Errorf("failed to reload market: %v", err)
+	}
+	if expectedVersion != nil && market.Version != *expectedVersion {
+		return nil, &VersionConflictError{ExpectedVersion: *expectedVersion, Market: &market}
+	}
+	if !CanTransitionMarket(market.Status, status) {
+		return nil, fmt.Errorf("%w: market %d cannot move from %s to %s", ErrInvalidTransition, marketID, market.Status, status)
+	}
+
+	version := market.Version
+	result := tx.Model(&models.Market{}).
+		Where("id = ? AND version = ?", marketID, version).
+		Updates(map[string]interface{}{
+			"status":  status,
+			"active":  status == models.MarketOpen,
+			"version": version + 1,
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update market status: %v", result.Error)
+	}
+	if result.RowsAffected != 1 {
+		var current models.Market
+		if err := tx.First(&current, marketID).Error; err != nil {
+			return nil, fmt.Errorf("failed to reload market: %v", err)
+		}
+		return nil, &VersionConflictError{ExpectedVersion: version, Market: &current}
+	}
+
+	return &models.MarketStatusResponse{
+		Success:        true,
+		Message:        fmt.Sprintf("Market %s", status),
+		MarketID:       marketID,
+		EventID:        market.EventID,
+		PreviousStatus: market.Status,
+		Status:         status,
+		UpdatedAt:      now,
+		Sequence:       sequence,
+		Version:        version + 1,
+	}, nil
+}
+
+func marketStatusMessage(response *models.MarketStatusResponse, reason string) MarketStatusMessage {
+	return MarketStatusMessage{
+		Type:           "market_status",
+		MarketID:       response.MarketID,
+		EventID:        response.EventID,
+		CompetitionID:  response.CompetitionID,
+		PreviousStatus: response.PreviousStatus,
+		Status:         response.Status,
+		Reason:         reason,
+		Timestamp:      response.UpdatedAt,
+		Sequence:       response.Sequence,
+	}
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// DefaultMarketTemplates returns the market templates a sport starts with,
+// by sport code. Sports without defaults start with none.
+func DefaultMarketTemplates(sportCode string) []models.MarketTemplate {
+	switch sportCode {
+	case "football":
+		return footballTemplates()
+	}
+	return nil
+}
+
+func footballTemplates() []models.MarketTemplate {
+	templates := []models.MarketTemplate{
+		{Name: "1X2", Type: models.MarketMatchWinner, Selections: []models.SelectionTemplate{
+			{Name: "1", Coefficient: 2.60, MinCoefficient: 1.01, MaxCoefficient: 50},
+			{Name: "X", Coefficient: 3.20, MinCoefficient: 1.50, MaxCoefficient: 20},
+			{Name: "2", Coefficient: 2.80, MinCoefficient: 1.01, MaxCoefficient: 50},
+		}},
+	}
+
+	// Initial over and under odds of the total goals lines.
+	totals := []struct{ line, over, under float64 }{
+		{0.5, 1.08, 8.00},
+		{1.5, 1.30, 3.40},
+		{2.5, 1.90, 1.90},
+		{3.5, 3.00, 1.38},
+		{4.5, 5.50, 1.14},
+	}
+	for _, total := range totals {
+		line := total.line
+		templates = append(templates, models.MarketTemplate{Name: "Total Goals", Type: models.MarketOverUnder, Line: &line, Selections: []models.SelectionTemplate{
+			{Name: "Over", Coefficient: total.over, MinCoefficient: 1.01, MaxCoefficient: 30},
+			{Name: "Under", Coefficient: total.under, MinCoefficient: 1.01, MaxCoefficient: 30},
+		}})
+	}
+
+	handicap := -0.5
+	return append(templates,
+		models.MarketTemplate{Name: "Both Teams To Score", Type: models.MarketBothToScore, Selections: []models.SelectionTemplate{
+			{Name: "Yes", Coefficient: 1.80, MinCoefficient: 1.01, MaxCoefficient: 10},
+			{Name: "No", Coefficient: 1.95, MinCoefficient: 1.01, MaxCoefficient: 10},
+		}},
+		models.MarketTemplate{Name: "Asian Handicap {team}", Type: models.MarketHandicap, Line: &handicap, PerTeam: true, Selections: []models.SelectionTemplate{
+			{Name: "{team}", Coefficient: 2.00, MinCoefficient: 1.01, MaxCoefficient: 20},
+			{Name: "{opponent}", Coefficient: 1.85, MinCoefficient: 1.01, MaxCoefficient: 20},
+		}},
+	)
+}
+
+// AddDefaultMarketTemplates gives the sport its default market templates.
+func AddDefaultMarketTemplates(db *gorm.DB, sport *models.Sport) error {
+	templates := DefaultMarketTemplates(sport.Code)
+	if len(templates) == 0 {
+		return nil
+	}
+	for i := range templates {
+		templates[i].SportID = sport.ID
+	}
+	if err := db.Create(&templates).Error; err != nil {
+		return fmt.Errorf("failed to create market templates of sport %d: %v", sport.ID, err)
+	}
+	return nil
+}
+
+// SeedMarketTemplates gives the existing sports their default market
+// templates when templates are introduced, that is while no template was ever
+// created. Templates removed later are not brought back.
+func SeedMarketTemplates(db *gorm.DB) error {
+	var count int64
+	if err := db.Unscoped().Model(&models.MarketTemplate{}).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to count market templates: %v", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var sports []models.Sport
+	if err := db.Find(&sports).Error; err != nil {
+		return fmt.Errorf("failed to load sports: %v", err)
+	}
+	for i := range sports {
+		if err := AddDefaultMarketTemplates(db, &sports[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateMarketTemplate checks the parameters of a template like those of a
+// market, which must be of a known type. Per team templates are required for
+// the types that take a team; types that take a player cannot be templated.
+func ValidateMarketTemplate(db *gorm.DB, template *models.MarketTemplate) error {
+	if template.Type == "" {
+		return fmt.Errorf("%w: templates need a market type", ErrInvalidMarketParams)
+	}
+	params, err := marketParamsOf(template.Type)
+	if err != nil {
+		return err
+	}
+	if params.player {
+		return fmt.Errorf("%w: %s markets need a player and cannot be templated", ErrInvalidMarketParams, template.Type)
+	}
+	if params.team && !template.PerTeam {
+		return fmt.Errorf("%w: %s templates must be per team", ErrInvalidMarketParams, template.Type)
+	}
+	if !params.team && template.PerTeam {
+		return fmt.Errorf("%w: %q templates cannot be per team", ErrInvalidMarketParams, template.Type)
+	}
+	return validateLineAndPeriod(template.Type, template.Line, template.Period)
+}
+
+// ValidateSelectionTemplate checks that the initial odds are within the
+// bounds of the selection.
+func ValidateSelectionTemplate(db *gorm.DB, template *models.SelectionTemplate) error {
+	if template.Coefficient < template.MinCoefficient || template.Coefficient > template.MaxCoefficient {
+		return fmt.Errorf("%w: odds %.2f out of bounds [%.2f, %.2f]", ErrCoefficientOutOfBounds, template.Coefficient, template.MinCoefficient, template.MaxCoefficient)
+	}
+	return nil
+}
+
+// GenerateEventMarkets creates the markets of the active templates of the
+// event's sport that the event does not have yet, with their selections at
+// the initial odds. Per team templates are generated once the event has its
+// two teams. Markets are open, or suspended when the event already started or
+// was postponed; finished and cancelled events get no markets.
+func GenerateEventMarkets(db *gorm.DB, eventID uint) ([]models.Market, error) {
+	var event models.Event
+	err := db.Preload("Competition").
+		Preload("Teams", func(tx *gorm.DB) *gorm.DB { return tx.Order("teams.id") }).
+		First(&event, eventID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to load event: %v", err)
+	}
+	markets := []models.Market{}
+	if event.Competition == nil || !EventAllowsTrading(event.Status) {
+		return markets, nil
+	}
+
+	var templates []models.MarketTemplate
+	err = db.Preload("Selections", func(tx *gorm.DB) *gorm.DB { return tx.Order("id") }).
+		Where("sport_id = ? AND active = ?", event.Competition.SportID, true).
+		Order("id").
+		Find(&templates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load market templates: %v", err)
+	}
+
+	var existing []models.Market
+	if err := db.Select("template_id", "team_id").Where("event_id = ? AND template_id IS NOT NULL", eventID).Find(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to load generated markets: %v", err)
+	}
+	generated := make(map[[2]uint]bool, len(existing))
+	for _, market := range existing {
+		key := [2]uint{*market.TemplateID, 0}
+		if market.TeamID != nil {
+			key[1] = *market.TeamID
+		}
+		generated[key] = true
+	}
+
+	now := time.Now()
+	for i := range templates {
+		template := &templates[i]
+		if !template.PerTeam {
+			if !generated[[2]uint{template.ID, 0}] {
+				markets = append(markets, marketFromTemplate(template, eventID, nil, strings.NewReplacer(), now))
+			}
+			continue
+		}
+		if len(event.Teams) != 2 {
+			continue
+		}
+		for j, team := range event.Teams {
+			if generated[[2]uint{template.ID, team.ID}] {
+				continue
+			}
+			teamID := team.ID
+			names := strings.NewReplacer("{team}", team.Name, "{opponent}", event.Teams[1-j].Name)
+			markets = append(markets, marketFromTemplate(template, eventID, &teamID, names, now))
+		}
+	}
+	if len(markets) == 0 {
+		return markets, nil
+	}
+
+	if err := db.Create(&markets).Error; err != nil {
+		return nil, fmt.Errorf("failed to create markets: %v", err)
+	}
+
+	// Active defaults to true on create, so markets of events in play are
+	// suspended once created.
+	if event.Status == models.EventLive || event.Status == models.EventHalfTime || event.Status == models.EventPostponed {
+		ids := make([]uint, len(markets))
+		for i := range markets {
+			ids[i] = markets[i].ID
+			markets[i].Status = models.MarketSuspended
+			markets[i].Active = false
+		}
+		err := db.Model(&models.Market{}).Where("id IN ?", ids).
+			Updates(map[string]interface{}{"status": models.MarketSuspended, "active": false}).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to suspend markets: %v", err)
+		}
+	}
+	return markets, nil
+}
+
+func marketFromTemplate(template *models.MarketTemplate, eventID uint, teamID *uint, names *strings.Replacer, now time.Time) models.Market {
+	templateID := template.ID
+	market := models.Market{
+		Name:       names.Replace(template.Name),
+		Type:       template.Type,
+		EventID:    eventID,
+		Line:       template.Line,
+		Period:     template.Period,
+		TeamID:     teamID,
+		TemplateID: &templateID,
+		Status:     models.MarketOpen,
+		Active:     true,
+		Selections: make([]models.Selection, len(template.Selections)),
+	}
+	for i, selection := range template.Selections {
+		market.Selections[i] = models.Selection{
+			Name:               names.Replace(selection.Name),
+			CurrentCoefficient: selection.Coefficient,
+			MinCoefficient:     selection.MinCoefficient,
+			MaxCoefficient:     selection.MaxCoefficient,
+			LastUpdated:        now,
+		}
+	}
+	return market
+}
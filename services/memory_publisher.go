@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"sync"
+)
+
+const memoryPublisherCapacity = 1000
+
+// MemoryPublisher keeps the most recent publications in memory. It stands in
+// for a real backend in tests and local runs.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	capacity int
+	messages []Publication
+}
+
+func NewMemoryPublisher(capacity int) *MemoryPublisher {
+	return &MemoryPublisher{
+		capacity: capacity,
+	}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, channels []string, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, Publication{
+		Channels: append([]string(nil), channels...),
+		Payload:  append([]byte(nil), payload...),
+	})
+	if len(p.messages) > p.capacity {
+		p.messages = p.messages[len(p.messages)-p.capacity:]
+	}
+	return nil
+}
+
+// Messages returns the retained publications, oldest first.
+func (p *MemoryPublisher) Messages() []Publication {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Publication(nil), p.messages...)
+}
+
+func (p *MemoryPublisher) Close() error {
+	return nil
+}
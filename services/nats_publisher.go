@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"strings"
+	"time"
+)
+
+// natsFlushTimeout bounds the flush of a publish whose context has no
+// deadline, which NATS requires.
+const natsFlushTimeout = 10 * time.Second
+
+// NATSPublisher publishes to NATS subjects. A channel becomes a subject by
+// replacing ":" with ".", so "odds:event:1" is published to "odds.event.1"
+// and consumers can subscribe to "odds.event.>".
+type NATSPublisher struct {
+	conn          *nats.Conn
+	subjectPrefix string
+}
+
+func NewNATSPublisher(url, subjectPrefix string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url,
+		nats.Name("sport"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %v", err)
+	}
+
+	return &NATSPublisher{
+		conn:          conn,
+		subjectPrefix: subjectPrefix,
+	}, nil
+}
+
+// Publish returns once the server has received every message.
+func (p *NATSPublisher) Publish(ctx context.Context, channels []string, payload []byte) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, natsFlushTimeout)
+		defer cancel()
+	}
+	for _, channel := range channels {
+		if err := p.conn.Publish(p.subject(channel), payload); err != nil {
+			return fmt.Errorf("failed to publish to %s: %v", p.subject(channel), err)
+		}
+	}
+	if err := p.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to flush NATS connection: %v", err)
+	}
+	return nil
+}
+
+func (p *NATSPublisher) subject(channel string) string {
+	return p.subjectPrefix + strings.ReplaceAll(channel, ":", ".")
+}
+
+func (p *NATSPublisher) Close() error {
+	p.conn.Close()
+	return nil
+}
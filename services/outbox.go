@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/config"
+	"github.com/VaheMuradyan/Sport/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// enqueueOutbox stores a publication in tx. It is delivered by the
+// OutboxDispatcher once tx commits, and never if tx rolls back.
+func enqueueOutbox(tx *gorm.DB, channels []string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox payload: %v", err)
+	}
+
+	message := models.OutboxMessage{
+		Channels:      strings.Join(channels, ","),
+		Payload:       string(payload),
+		Status:        models.OutboxPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := tx.Create(&message).Error; err != nil {
+		return fmt.Errorf("failed to write outbox message: %v", err)
+	}
+	return nil
+}
+
+// OutboxDispatcher delivers pending outbox messages to the publisher in the
+// order they were written. Delivery is at least once: a message that fails is
+// retried with exponential backoff, possibly after newer messages, and is
+// dead-lettered after the configured number of attempts.
+//
+// Several dispatchers may share the outbox, one per instance. Each claims its
+// batch for the configured lease, so a message is published by one of them
+// unless its dispatcher stops or stalls past the lease.
+type OutboxDispatcher struct {
+	db        *gorm.DB
+	publisher Publisher
+	cfg       config.OutboxConfig
+	id        string
+	wake      chan struct{}
+}
+
+func NewOutboxDispatcher(db *gorm.DB, publisher Publisher, cfg config.OutboxConfig) *OutboxDispatcher {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &OutboxDispatcher{
+		db:        db,
+		publisher: publisher,
+		cfg:       cfg,
+		id:        fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Notify wakes the dispatcher so a freshly committed message is delivered
+// without waiting for the next poll.
+func (d *OutboxDispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers messages until ctx is cancelled.
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	lastPurge := time.Time{}
+	for {
+		for d.dispatchBatch(ctx) {
+		}
+
+		if time.Since(lastPurge) >= time.Hour {
+			d.purgeDelivered()
+			lastPurge = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// dispatchBatch delivers up to one batch of due messages. It reports whether
+// a full batch was delivered, so the caller can continue without waiting.
+func (d *OutboxDispatcher) dispatchBatch(ctx context.Context) bool {
+	messages, err := d.claim()
+	if err != nil {
+		log.Printf("❌ Failed to claim outbox messages: %v", err)
+		return false
+	}
+
+	if batcher, ok := d.publisher.(BatchPublisher); ok {
+		publications := make([]Publication, len(messages))
+		for i, message := range messages {
+			publications[i] = Publication{
+				Channels: strings.Split(message.Channels, ","),
+				Payload:  []byte(message.Payload),
+			}
+		}
+
+		delivered := true
+		for i, err := range batcher.PublishBatch(ctx, publications) {
+			if !d.record(&messages[i], err) {
+				delivered = false
+			}
+		}
+		return delivered && len(messages) == d.cfg.BatchSize
+	}
+
+	for i := range messages {
+		message := &messages[i]
+		err := d.publisher.Publish(ctx, strings.Split(message.Channels, ","), []byte(message.Payload))
+		if !d.record(message, err) {
+			// The backend is most likely unavailable, leave the rest of the
+			// batch for the next poll.
+			d.release(messages[i+1:])
+			return false
+		}
+	}
+	return len(messages) == d.cfg.BatchSize
+}
+
+// claim locks up to one batch of due messages for the lease of this
+// dispatcher. Messages locked by another dispatcher are skipped, those whose
+// lease expired are claimed again.
+func (d *OutboxDispatcher) claim() ([]models.OutboxMessage, error) {
+	var messages []models.OutboxMessage
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.OutboxPending, now).
+			Where("locked_until IS NULL OR locked_until <= ?", now).
+			Order("id").
+			Limit(d.cfg.BatchSize).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(messages))
+		for i := range messages {
+			ids[i] = messages[i].ID
+		}
+		return tx.Model(&models.OutboxMessage{}).Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"claimed_by":   d.id,
+				"locked_until": now.Add(d.cfg.Lease),
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// release gives up the claim on messages that were not attempted.
+func (d *OutboxDispatcher) release(messages []models.OutboxMessage) {
+	if len(messages) == 0 {
+		return
+	}
+	ids := make([]uint, len(messages))
+	for i := range messages {
+		ids[i] = messages[i].ID
+	}
+	err := d.db.Model(&models.OutboxMessage{}).
+		Where("id IN ? AND claimed_by = ?", ids, d.id).
+		Updates(map[string]interface{}{"claimed_by": "", "locked_until": nil}).Error
+	if err != nil {
+		log.Printf("❌ Failed to release outbox messages: %v", err)
+	}
+}
+
+// record stores the outcome of a delivery attempt and reports whether the
+// message was delivered.
+func (d *OutboxDispatcher) record(message *models.OutboxMessage, err error) bool {
+	attempts := message.Attempts + 1
+
+	if err == nil {
+		now := time.Now()
+		d.update(message, map[string]interface{}{
+			"status":       models.OutboxDelivered,
+			"attempts":     attempts,
+			"delivered_at": &now,
+			"last_error":   "",
+		})
+		log.Printf("✅ Published outbox message %d to channels %s", message.ID, message.Channels)
+		return true
+	}
+
+	if attempts >= d.cfg.MaxAttempts {
+		d.update(message, map[string]interface{}{
+			"status":     models.OutboxDead,
+			"attempts":   attempts,
+			"last_error": err.Error(),
+		})
+		log.Printf("💀 Outbox message %d dead-lettered after %d attempts: %v", message.ID, attempts, err)
+		return false
+	}
+
+	backoff := d.backoff(attempts)
+	d.update(message, map[string]interface{}{
+		"attempts":        attempts,
+		"next_attempt_at": time.Now().Add(backoff),
+		"last_error":      err.Error(),
+	})
+	log.Printf("⚠️ Failed to publish outbox message %d (attempt %d), retrying in %v: %v", message.ID, attempts, backoff, err)
+	return false
+}
+
+// backoff doubles the initial delay for every failed attempt up to the
+// configured maximum.
+func (d *OutboxDispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.InitialBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.cfg.MaxBackoff {
+		delay = d.cfg.MaxBackoff
+	}
+	return delay
+}
+
+// update records an attempt and releases the claim on the message, unless
+// the lease expired and another dispatcher claimed it since.
+func (d *OutboxDispatcher) update(message *models.OutboxMessage, values map[string]interface{}) {
+	values["claimed_by"] = ""
+	values["locked_until"] = nil
+	result := d.db.Model(message).Where("claimed_by = ?", d.id).Updates(values)
+	if result.Error != nil {
+		log.Printf("❌ Failed to update outbox message %d: %v", message.ID, result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		log.Printf("⚠️ Lease of outbox message %d expired before its attempt was recorded", message.ID)
+	}
+}
+
+func (d *OutboxDispatcher) purgeDelivered() {
+	cutoff := time.Now().Add(-d.cfg.Retention)
+	err := d.db.Unscoped().
+		Where("status = ? AND delivered_at < ?", models.OutboxDelivered, cutoff).
+		Delete(&models.OutboxMessage{}).Error
+	if err != nil {
+		log.Printf("❌ Failed to purge delivered outbox messages: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/models"
+	"gorm.io/gorm"
+	"log"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// PermissionService decides what a user may do and audits every denial.
+type PermissionService struct {
+	db *gorm.DB
+}
+
+func NewPermissionService(db *gorm.DB) *PermissionService {
+	return &PermissionService{
+		db: db,
+	}
+}
+
+// RequireRole allows the action when the user has one of the given roles.
+func (s *PermissionService) RequireRole(userID uint, action, resource string, resourceID uint, roles ...string) error {
+	user, err := s.loadUser(userID)
+	if err != nil {
+		return s.deny(userID, action, resource, resourceID, err.Error())
+	}
+
+	for _, role := range roles {
+		if user.Role == role {
+			return nil
+		}
+	}
+
+	return s.deny(userID, action, resource, resourceID, fmt.Sprintf("role %s is not allowed", user.Role))
+}
+
+// CanTradeMarket allows admins to move any market, and traders and feed bots
+// to move markets in a sport or competition they hold a permission for.
+func (s *PermissionService) CanTradeMarket(userID uint, market *models.Market) error {
+	return s.canManageMarket(userID, "coefficient.update", market)
+}
+
+// CanChangeMarketStatus allows the users who may trade a market to suspend,
+// reopen, close, settle or void it.
+func (s *PermissionService) CanChangeMarketStatus(userID uint, market *models.Market) error {
+	return s.canManageMarket(userID, "market.status", market)
+}
+
+// CanChangeEventStatus allows the users who may trade the markets of an event
+// to move it through its lifecycle.
+func (s *PermissionService) CanChangeEventStatus(userID uint, event *models.Event) error {
+	return s.canManageEvent(userID, "event.status", "event", event.ID, event.ID)
+}
+
+// CanRecordIncident allows the users who may trade the markets of an event to
+// record what happens in it.
+func (s *PermissionService) CanRecordIncident(userID uint, event *models.Event) error {
+	return s.canManageEvent(userID, "event.incident", "event", event.ID, event.ID)
+}
+
+func (s *PermissionService) canManageMarket(userID uint, action string, market *models.Market) error {
+	return s.canManageEvent(userID, action, "market", market.ID, market.EventID)
+}
+
+// canManageEvent allows admins to act on anything under the event, and
+// traders and feed bots when they hold a permission for its sport or
+// competition.
+func (s *PermissionService) canManageEvent(userID uint, action, resource string, resourceID, eventID uint) error {
+	user, err := s.loadUser(userID)
+	if err != nil {
+		return s.deny(userID, action, resource, resourceID, err.Error())
+	}
+
+	switch user.Role {
+	case models.RoleAdmin:
+		return nil
+	case models.RoleTrader, models.RoleFeedBot:
+	default:
+		return s.deny(userID, action, resource, resourceID, fmt.Sprintf("role %s cannot trade", user.Role))
+	}
+
+	var competition models.Competition
+	err = s.db.Select("competitions.id", "competitions.sport_id").
+		Joins("JOIN events ON events.competition_id = competitions.id").
+		Where("events.id = ?", eventID).
+		First(&competition).Error
+	if err != nil {
+		return s.deny(userID, action, resource, resourceID, fmt.Sprintf("cannot resolve competition of event %d: %v", eventID, err))
+	}
+
+	var count int64
+	err = s.db.Model(&models.TradingPermission{}).
+		Where("user_id = ?", userID).
+		Where("sport_id = ? OR competition_id = ?", competition.SportID, competition.ID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to check trading permissions: %v", err)
+	}
+	if count == 0 {
+		return s.deny(userID, action, resource, resourceID,
+			fmt.Sprintf("no permission for competition %d or sport %d", competition.ID, competition.SportID))
+	}
+
+	return nil
+}
+
+func (s *PermissionService) loadUser(userID uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user %d does not exist", userID)
+		}
+		return nil, fmt.Errorf("failed to load user %d: %v", userID, err)
+	}
+	return &user, nil
+}
+
+func (s *PermissionService) deny(userID uint, action, resource string, resourceID uint, reason string) error {
+	entry := models.AuditLog{
+		UserID:     userID,
+		Action:     action,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Reason:     reason,
+	}
+	if err := s.db.Create(&entry).Error; err != nil {
+		log.Printf("❌ Failed to audit denied %s on %s %d by user %d: %v", action, resource, resourceID, userID, err)
+	}
+
+	log.Printf("🚫 Denied %s on %s %d for user %d: %s", action, resource, resourceID, userID, reason)
+	return fmt.Errorf("%w: %s", ErrPermissionDenied, reason)
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/config"
+)
+
+// Publisher delivers a JSON payload to a set of channels. Channels are named
+// as in channels.go; every backend maps them onto its own addressing.
+type Publisher interface {
+	Publish(ctx context.Context, channels []string, payload []byte) error
+	Close() error
+}
+
+// Publication is one payload for a set of channels.
+type Publication struct {
+	Channels []string
+	Payload  []byte
+}
+
+// BatchPublisher is implemented by publishers that deliver several
+// publications in one round trip. PublishBatch returns one error per
+// publication, nil for those that were delivered.
+type BatchPublisher interface {
+	Publisher
+	PublishBatch(ctx context.Context, publications []Publication) []error
+}
+
+// NewPublisher creates the publishers listed in cfg.Backends. Several
+// backends are combined into a FanoutPublisher. With batch enabled
+// Centrifugo is published to through a BatchingPublisher.
+func NewPublisher(cfg config.PublisherConfig, centrifugo CentrifugoAPI, batch config.CentrifugoBatchConfig) (Publisher, error) {
+	publishers := make(map[string]Publisher, len(cfg.Backends))
+	for _, backend := range cfg.Backends {
+		publisher, err := newBackendPublisher(backend, cfg, centrifugo, batch)
+		if err != nil {
+			for _, created := range publishers {
+				created.Close()
+			}
+			return nil, fmt.Errorf("failed to create %s publisher: %v", backend, err)
+		}
+		publishers[backend] = publisher
+	}
+
+	if len(publishers) == 1 {
+		for _, publisher := range publishers {
+			return publisher, nil
+		}
+	}
+	return NewFanoutPublisher(publishers), nil
+}
+
+func newBackendPublisher(backend string, cfg config.PublisherConfig, centrifugo CentrifugoAPI, batch config.CentrifugoBatchConfig) (Publisher, error) {
+	switch backend {
+	case config.BackendCentrifugo:
+		if batch.Enabled {
+			return NewBatchingPublisher(centrifugo, batch.Window, batch.MaxSize), nil
+		}
+		return centrifugo, nil
+	case config.BackendMemory:
+		return NewMemoryPublisher(memoryPublisherCapacity), nil
+	case config.BackendNATS:
+		return NewNATSPublisher(cfg.NATS.URL, cfg.NATS.SubjectPrefix)
+	case config.BackendRedis:
+		return NewRedisPublisher(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB, cfg.Redis.ChannelPrefix), nil
+	case config.BackendKafka:
+		return NewKafkaPublisher(cfg.Kafka.Brokers, cfg.Kafka.Topic), nil
+	case config.BackendWebhook:
+		return NewWebhookPublisher(cfg.Webhook.URL, cfg.Webhook.Secret, cfg.Webhook.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown backend %q", backend)
+	}
+}
@@ -0,0 +1,387 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/VaheMuradyan/Sport/config"
+	"github.com/alicebob/miniredis/v2"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testChannels = []string{"odds:market:7", "odds:event:3"}
+
+const testPayload = `{"market_id":7}`
+
+// stubPublisher records its publications and fails with err.
+type stubPublisher struct {
+	mu        sync.Mutex
+	err       error
+	published []Publication
+}
+
+func (p *stubPublisher) Publish(ctx context.Context, channels []string, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = append(p.published, Publication{Channels: channels, Payload: payload})
+	return p.err
+}
+
+func (p *stubPublisher) Close() error {
+	return p.err
+}
+
+func TestMemoryPublisherKeepsMostRecent(t *testing.T) {
+	publisher := NewMemoryPublisher(2)
+	for _, payload := range []string{"1", "2", "3"} {
+		if err := publisher.Publish(context.Background(), testChannels, []byte(payload)); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	messages := publisher.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(messages))
+	}
+	if string(messages[0].Payload) != "2" || string(messages[1].Payload) != "3" {
+		t.Errorf("got payloads %q and %q, want 2 and 3", messages[0].Payload, messages[1].Payload)
+	}
+	if strings.Join(messages[0].Channels, ",") != strings.Join(testChannels, ",") {
+		t.Errorf("got channels %v, want %v", messages[0].Channels, testChannels)
+	}
+}
+
+func TestMemoryPublisherCopiesInput(t *testing.T) {
+	publisher := NewMemoryPublisher(10)
+	channels := []string{"odds:market:1"}
+	payload := []byte("1")
+	publisher.Publish(context.Background(), channels, payload)
+	channels[0] = "changed"
+	payload[0] = '2'
+
+	message := publisher.Messages()[0]
+	if message.Channels[0] != "odds:market:1" || string(message.Payload) != "1" {
+		t.Errorf("publication changed with the caller's slices: %v %q", message.Channels, message.Payload)
+	}
+}
+
+func TestWebhookPublisherSignsRequest(t *testing.T) {
+	var (
+		body      []byte
+		signature string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(webhookSignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, "secret", time.Second)
+	if err := publisher.Publish(context.Background(), testChannels, []byte(testPayload)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	var request webhookRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("invalid body %s: %v", body, err)
+	}
+	if strings.Join(request.Channels, ",") != strings.Join(testChannels, ",") || string(request.Data) != testPayload {
+		t.Errorf("got body %s", body)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); signature != want {
+		t.Errorf("got signature %q, want %q", signature, want)
+	}
+}
+
+func TestWebhookPublisherUnsigned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if signature := r.Header.Get(webhookSignatureHeader); signature != "" {
+			t.Errorf("got signature %q without a secret", signature)
+		}
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, "", time.Second)
+	if err := publisher.Publish(context.Background(), testChannels, []byte(testPayload)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+}
+
+func TestWebhookPublisherFailsOnNon2xx(t *testing.T) {
+	for _, status := range []int{http.StatusMovedPermanently, http.StatusBadRequest, http.StatusServiceUnavailable} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		publisher := NewWebhookPublisher(server.URL, "", time.Second)
+		if err := publisher.Publish(context.Background(), testChannels, []byte(testPayload)); err == nil {
+			t.Errorf("status %d: got no error", status)
+		}
+		server.Close()
+	}
+}
+
+func TestWebhookPublisherFailsWhenUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, "", time.Second)
+	if err := publisher.Publish(context.Background(), testChannels, []byte(testPayload)); err == nil {
+		t.Error("got no error")
+	}
+}
+
+func TestFanoutPublisherPublishesToAll(t *testing.T) {
+	first, second := &stubPublisher{}, &stubPublisher{}
+	publisher := NewFanoutPublisher(map[string]Publisher{"first": first, "second": second})
+
+	if err := publisher.Publish(context.Background(), testChannels, []byte(testPayload)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(first.published) != 1 || len(second.published) != 1 {
+		t.Errorf("got %d and %d publications, want 1 each", len(first.published), len(second.published))
+	}
+}
+
+func TestFanoutPublisherJoinsErrors(t *testing.T) {
+	errFirst, errSecond := errors.New("first down"), errors.New("second down")
+	ok := &stubPublisher{}
+	publisher := NewFanoutPublisher(map[string]Publisher{
+		"ok":     ok,
+		"first":  &stubPublisher{err: errFirst},
+		"second": &stubPublisher{err: errSecond},
+	})
+
+	err := publisher.Publish(context.Background(), testChannels, []byte(testPayload))
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	for _, want := range []string{"first: first down", "second: second down"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "ok:") {
+		t.Errorf("error %q names the backend that succeeded", err)
+	}
+	if len(ok.published) != 1 {
+		t.Errorf("got %d publications on the working backend, want 1", len(ok.published))
+	}
+
+	if err := publisher.Close(); err == nil || !strings.Contains(err.Error(), "first: first down") {
+		t.Errorf("Close: got %v", err)
+	}
+}
+
+func TestFanoutPublisherBatchErrors(t *testing.T) {
+	memory := NewMemoryPublisher(10)
+	publisher := NewFanoutPublisher(map[string]Publisher{
+		"memory": memory,
+		"down":   &stubPublisher{err: errors.New("down")},
+	})
+	publications := []Publication{
+		{Channels: testChannels, Payload: []byte("1")},
+		{Channels: testChannels, Payload: []byte("2")},
+	}
+
+	errs := publisher.PublishBatch(context.Background(), publications)
+	if len(errs) != len(publications) {
+		t.Fatalf("got %d errors, want %d", len(errs), len(publications))
+	}
+	for i, err := range errs {
+		if err == nil || !strings.Contains(err.Error(), "down: down") {
+			t.Errorf("publication %d: got %v", i, err)
+		}
+	}
+	if len(memory.Messages()) != 2 {
+		t.Errorf("got %d publications on the working backend, want 2", len(memory.Messages()))
+	}
+}
+
+func TestNewPublisherSelectsBackends(t *testing.T) {
+	cfg := config.Default()
+
+	cfg.Publisher.Backends = []string{config.BackendMemory}
+	publisher, err := NewPublisher(cfg.Publisher, nil, cfg.Centrifugo.Batch)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	if _, ok := publisher.(*MemoryPublisher); !ok {
+		t.Errorf("got %T for a single memory backend, want *MemoryPublisher", publisher)
+	}
+
+	cfg.Publisher.Backends = []string{config.BackendMemory, config.BackendWebhook}
+	publisher, err = NewPublisher(cfg.Publisher, nil, cfg.Centrifugo.Batch)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	fanout, ok := publisher.(*FanoutPublisher)
+	if !ok {
+		t.Fatalf("got %T for two backends, want *FanoutPublisher", publisher)
+	}
+	if _, ok := fanout.publishers[config.BackendWebhook].(*WebhookPublisher); !ok {
+		t.Errorf("got %T for the webhook backend", fanout.publishers[config.BackendWebhook])
+	}
+}
+
+func TestNewPublisherRejectsUnknownBackend(t *testing.T) {
+	cfg := config.Default()
+	cfg.Publisher.Backends = []string{config.BackendMemory, "carrier_pigeon"}
+
+	_, err := NewPublisher(cfg.Publisher, nil, cfg.Centrifugo.Batch)
+	if err == nil || !strings.Contains(err.Error(), `unknown backend "carrier_pigeon"`) {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestNATSPublisher(t *testing.T) {
+	server := natsserver.RunRandClientPortServer()
+	defer server.Shutdown()
+
+	subscriber, err := nats.Connect(server.ClientURL())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer subscriber.Close()
+	subscription, err := subscriber.SubscribeSync("sport.odds.>")
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	subscriber.Flush()
+
+	publisher, err := NewNATSPublisher(server.ClientURL(), "sport.")
+	if err != nil {
+		t.Fatalf("NewNATSPublisher: %v", err)
+	}
+	defer publisher.Close()
+	if err := publisher.Publish(context.Background(), testChannels, []byte(testPayload)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	for _, want := range []string{"sport.odds.market.7", "sport.odds.event.3"} {
+		message, err := subscription.NextMsg(time.Second)
+		if err != nil {
+			t.Fatalf("no message on %s: %v", want, err)
+		}
+		if message.Subject != want || string(message.Data) != testPayload {
+			t.Errorf("got %q on %s, want it on %s", message.Data, message.Subject, want)
+		}
+	}
+}
+
+func TestNATSPublisherFailsWhenDisconnected(t *testing.T) {
+	server := natsserver.RunRandClientPortServer()
+	publisher, err := NewNATSPublisher(server.ClientURL(), "")
+	if err != nil {
+		t.Fatalf("NewNATSPublisher: %v", err)
+	}
+	defer publisher.Close()
+	server.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := publisher.Publish(ctx, testChannels, []byte(testPayload)); err == nil {
+		t.Error("got no error")
+	}
+}
+
+func TestRedisPublisher(t *testing.T) {
+	server := miniredis.RunT(t)
+	subscriber := server.NewSubscriber()
+	defer subscriber.Close()
+	subscriber.Psubscribe("sport:odds:*")
+
+	// The server delivers to the subscriber before replying to the publish.
+	received := make(chan miniredis.PubsubPmessage, len(testChannels))
+	go func() {
+		for i := 0; i < len(testChannels); i++ {
+			received <- <-subscriber.Pmessages()
+		}
+	}()
+
+	publisher := NewRedisPublisher(server.Addr(), "", 0, "sport:")
+	defer publisher.Close()
+	if err := publisher.Publish(context.Background(), testChannels, []byte(testPayload)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	for _, want := range []string{"sport:odds:market:7", "sport:odds:event:3"} {
+		select {
+		case message := <-received:
+			if message.Channel != want || message.Message != testPayload {
+				t.Errorf("got %q on %s, want it on %s", message.Message, message.Channel, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no message on %s", want)
+		}
+	}
+}
+
+func TestRedisPublisherFailsWhenUnavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.SetError("LOADING")
+
+	publisher := NewRedisPublisher(server.Addr(), "", 0, "")
+	defer publisher.Close()
+	if err := publisher.Publish(context.Background(), testChannels, []byte(testPayload)); err == nil {
+		t.Error("got no error")
+	}
+}
+
+// stubKafkaWriter stands in for kafka.Writer.
+type stubKafkaWriter struct {
+	err      error
+	messages []kafka.Message
+}
+
+func (w *stubKafkaWriter) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
+	w.messages = append(w.messages, messages...)
+	return w.err
+}
+
+func (w *stubKafkaWriter) Close() error {
+	return nil
+}
+
+func TestKafkaPublisher(t *testing.T) {
+	writer := &stubKafkaWriter{}
+	publisher := &KafkaPublisher{writer: writer}
+	if err := publisher.Publish(context.Background(), testChannels, []byte(testPayload)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	if len(writer.messages) != 1 {
+		t.Fatalf("got %d records, want 1", len(writer.messages))
+	}
+	message := writer.messages[0]
+	if string(message.Key) != "odds:market:7" || string(message.Value) != testPayload {
+		t.Errorf("got key %q and value %q", message.Key, message.Value)
+	}
+	if len(message.Headers) != 1 || message.Headers[0].Key != "channels" || string(message.Headers[0].Value) != "odds:market:7,odds:event:3" {
+		t.Errorf("got headers %v", message.Headers)
+	}
+}
+
+func TestKafkaPublisherFailsOnWriteError(t *testing.T) {
+	publisher := &KafkaPublisher{writer: &stubKafkaWriter{err: errors.New("not enough replicas")}}
+	err := publisher.Publish(context.Background(), testChannels, []byte(testPayload))
+	if err == nil || !strings.Contains(err.Error(), "not enough replicas") {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisPublisher publishes to Redis Pub/Sub channels named like the
+// Centrifugo ones, so consumers can PSUBSCRIBE to "odds:event:*".
+type RedisPublisher struct {
+	client        *redis.Client
+	channelPrefix string
+}
+
+func NewRedisPublisher(addr, password string, db int, channelPrefix string) *RedisPublisher {
+	return &RedisPublisher{
+		client: redis.NewClient(&redis.Options{
+			Addr:     addr,
+			Password: password,
+			DB:       db,
+		}),
+		channelPrefix: channelPrefix,
+	}
+}
+
+func (p *RedisPublisher) Publish(ctx context.Context, channels []string, payload []byte) error {
+	pipe := p.client.Pipeline()
+	for _, channel := range channels {
+		pipe.Publish(ctx, p.channelPrefix+channel, payload)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to publish to redis: %v", err)
+	}
+	return nil
+}
+
+func (p *RedisPublisher) Close() error {
+	return p.client.Close()
+}
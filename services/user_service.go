@@ -0,0 +1,197 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/VaheMuradyan/Sport/auth"
+	"github.com/VaheMuradyan/Sport/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUsernameTaken      = errors.New("username already taken")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidPermission  = errors.New("invalid trading permission")
+	ErrPermissionNotFound = errors.New("trading permission not found")
+)
+
+type UserService struct {
+	db *gorm.DB
+}
+
+func NewUserService(db *gorm.DB) *UserService {
+	return &UserService{
+		db: db,
+	}
+}
+
+func (s *UserService) Register(username, password string) (*models.User, error) {
+	var count int64
+	if err := s.db.Model(&models.User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to check username: %v", err)
+	}
+	if count > 0 {
+		return nil, ErrUsernameTaken
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %v", err)
+	}
+
+	user := models.User{
+		Username:     username,
+		PasswordHash: hash,
+	}
+	if err := s.db.Create(&user).Error; err != nil {
+		return nil, fmt.Errorf("failed to create user: %v", err)
+	}
+
+	return &user, nil
+}
+
+func (s *UserService) Authenticate(username, password string) (*models.User, error) {
+	var user models.User
+	if err := s.db.Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to load user: %v", err)
+	}
+
+	if !auth.CheckPassword(user.PasswordHash, password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &user, nil
+}
+
+func (s *UserService) GetUser(userID uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.Preload("Permissions").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to load user: %v", err)
+	}
+	return &user, nil
+}
+
+func (s *UserService) ListUsers() ([]models.User, error) {
+	var users []models.User
+	if err := s.db.Preload("Permissions").Order("id").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to list users: %v", err)
+	}
+	return users, nil
+}
+
+func (s *UserService) SetRole(userID uint, role string) (*models.User, error) {
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(user).Update("role", role).Error; err != nil {
+		return nil, fmt.Errorf("failed to update role: %v", err)
+	}
+	return user, nil
+}
+
+// AddPermission grants a trading permission for exactly one sport or one
+// competition.
+func (s *UserService) AddPermission(userID uint, sportID, competitionID *uint) (*models.TradingPermission, error) {
+	if _, err := s.GetUser(userID); err != nil {
+		return nil, err
+	}
+
+	if (sportID == nil) == (competitionID == nil) {
+		return nil, fmt.Errorf("%w: set exactly one of sport_id and competition_id", ErrInvalidPermission)
+	}
+
+	var target interface{} = &models.Sport{}
+	targetID := sportID
+	if competitionID != nil {
+		target = &models.Competition{}
+		targetID = competitionID
+	}
+	if err := s.db.First(target, *targetID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: target %d does not exist", ErrInvalidPermission, *targetID)
+		}
+		return nil, fmt.Errorf("failed to load permission target: %v", err)
+	}
+
+	permission := models.TradingPermission{
+		UserID:        userID,
+		SportID:       sportID,
+		CompetitionID: competitionID,
+	}
+	if err := s.db.Create(&permission).Error; err != nil {
+		return nil, fmt.Errorf("failed to create permission: %v", err)
+	}
+	return &permission, nil
+}
+
+func (s *UserService) RemovePermission(userID, permissionID uint) error {
+	result := s.db.Where("user_id = ?", userID).Delete(&models.TradingPermission{}, permissionID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove permission: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrPermissionNotFound
+	}
+	return nil
+}
+
+// EnsureAccount creates the account with the given role if it does not exist
+// yet. An existing account is left as it is, unless reset is set, in which
+// case its password and role are reset. It is used to provision the bootstrap
+// admin and service accounts at startup.
+func (s *UserService) EnsureAccount(username, password, role string, reset bool) (*models.User, error) {
+	var user models.User
+	err := s.db.Where("username = ?", username).First(&user).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to load account %s: %v", username, err)
+	}
+	exists := err == nil
+	if exists && !reset {
+		return &user, nil
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %v", err)
+	}
+
+	if !exists {
+		user = models.User{Username: username, PasswordHash: hash, Role: role}
+		if err := s.db.Create(&user).Error; err != nil {
+			return nil, fmt.Errorf("failed to create account %s: %v", username, err)
+		}
+		return &user, nil
+	}
+
+	if err := s.db.Model(&user).Updates(map[string]interface{}{"password": hash, "role": role}).Error; err != nil {
+		return nil, fmt.Errorf("failed to update account %s: %v", username, err)
+	}
+	return &user, nil
+}
+
+// EnsureSportPermission grants the user a permission for the sport unless it
+// already has one.
+func (s *UserService) EnsureSportPermission(userID, sportID uint) error {
+	var count int64
+	err := s.db.Model(&models.TradingPermission{}).
+		Where("user_id = ? AND sport_id = ?", userID, sportID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to check permissions: %v", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	_, err = s.AddPermission(userID, &sportID, nil)
+	return err
+}